@@ -8,6 +8,7 @@ import (
 // APIClient A client with extra helper methods for common actions
 type APIClient struct {
 	Client
+	fanOutWorkers int
 }
 
 // NewAPIClient Creates a new APIClient
@@ -16,7 +17,9 @@ func NewAPIClient() *APIClient {
 		Client{
 			Transport: &http.Transport{Proxy: http.ProxyFromEnvironment},
 			//verbose:   true,
-		}}
+		},
+		DefaultFanOutWorkers,
+	}
 }
 
 //////////////////////////////
@@ -84,25 +87,39 @@ func (c *APIClient) GetTask(task *Task) error {
 	return nil
 }
 
-// GetTaskListByDevice ... gets a list of tasks for the device & returns a ref to the tasks and any error
+// GetTaskIdsByDevice ... gets the list of task IDs for the device & returns a ref to the list and any error
 // https://www.dotcom-monitor.com/wiki/knowledge-base/get-task-list-by-device/
-func (c *APIClient) GetTaskListByDevice(device *Device, tasks *[]Task) error {
+func (c *APIClient) GetTaskIdsByDevice(device *Device, taskIDs *[]int) error {
 	apiPath := fmt.Sprintf("device/%s/tasks", fmt.Sprint(device.ID))
 
+	if err := c.Do("GET", apiPath, nil, &taskIDs); err != nil {
+		return fmt.Errorf("Failed to get task list by device: %s", err)
+	}
+
+	return nil
+}
+
+// GetTaskListByDevice ... gets a list of tasks for the device & returns a ref to the tasks and any error
+func (c *APIClient) GetTaskListByDevice(device *Device, tasks *[]Task) error {
 	var resp []int
 
-	if err := c.Do("GET", apiPath, nil, &resp); err != nil {
-		return fmt.Errorf("Failed to get task list by device: %s", err)
+	if err := c.GetTaskIdsByDevice(device, &resp); err != nil {
+		return err
 	}
 
-	for _, item := range resp {
-		task := &Task{}
-		task.ID = item
-		if taskErr := c.GetTask(task); taskErr != nil {
+	// get full task details for each task ID in parallel
+	taskList := make([]Task, len(resp))
+	err := c.fanOut(len(resp), func(i int) error {
+		taskList[i].ID = resp[i]
+		if taskErr := c.GetTask(&taskList[i]); taskErr != nil {
 			return fmt.Errorf("GetTaskListByDevice failed: %v", taskErr)
 		}
-		*tasks = append(*tasks, *task)
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+	*tasks = append(*tasks, taskList...)
 
 	return nil
 }
@@ -126,6 +143,27 @@ func (c *APIClient) GetDeviceTasksByName(deviceID int, name string, tasks *[]Tas
 	return nil
 }
 
+// GetTasks ... gets the list of tasks for every device on the given platform & returns a ref to the tasks and any error
+func (c *APIClient) GetTasks(platformID int, tasks *[]Task) error {
+	var allDeviceIds []int
+
+	// first, get all device IDs on the platform
+	if devicesErr := c.GetDeviceIds(platformID, &allDeviceIds); devicesErr != nil {
+		return fmt.Errorf("GetTasks failed: %v", devicesErr)
+	}
+
+	for _, item := range allDeviceIds {
+		device := &Device{}
+		device.ID = item
+		// then get the task list for each device ID
+		if taskErr := c.GetTaskListByDevice(device, tasks); taskErr != nil {
+			return fmt.Errorf("GetTasks failed: %v", taskErr)
+		}
+	}
+
+	return nil
+}
+
 // UpdateTask ... updates the task by ID & returns a ref to the task and any error
 // https://wiki.dotcom-monitor.com/knowledge-base/edit-task/
 func (c *APIClient) UpdateTask(task *Task) error {
@@ -215,8 +253,9 @@ func (c *APIClient) DeleteDevice(device *Device) error {
 	return nil
 }
 
-// GetDevicesByName ... gets a list of devices on the given platform based on the given name
-func (c *APIClient) GetDevicesByName(platformID int, name string, devices *[]Device) error {
+// GetDeviceIds ... gets the list of device IDs on the given platform & returns a ref to the list and any error
+// https://www.dotcom-monitor.com/wiki/knowledge-base/get-list-of-devices-by-platform/
+func (c *APIClient) GetDeviceIds(platformID int, deviceIDs *[]int) error {
 	// ensure platform is enabled
 	available, err := c.IsPlatformAvailable(platformID)
 	if err != nil {
@@ -225,24 +264,54 @@ func (c *APIClient) GetDevicesByName(platformID int, name string, devices *[]Dev
 		return fmt.Errorf("Platform ID %v is not available for this account", platformID)
 	}
 
-	deviceIdsAPIPath := fmt.Sprintf("devices/%s", fmt.Sprint(platformID))
-	var platformDevicesResp []int
+	apiPath := fmt.Sprintf("devices/%s", fmt.Sprint(platformID))
 
-	if err := c.Do("GET", deviceIdsAPIPath, nil, &platformDevicesResp); err != nil {
+	if err := c.Do("GET", apiPath, nil, &deviceIDs); err != nil {
 		return fmt.Errorf("Failed to get device ID's by platform ID: %s", err)
 	}
 
-	for _, item := range platformDevicesResp {
-		device := &Device{}
-		device.ID = item
-		if deviceErr := c.GetDevice(device); deviceErr != nil {
-			return fmt.Errorf("GetDevicesByName failed: %v", deviceErr)
+	return nil
+}
+
+// GetDevices ... gets the list of devices on the given platform & returns a ref to the devices and any error
+func (c *APIClient) GetDevices(platformID int, devices *[]Device) error {
+	var allDeviceIds []int
+
+	// first, get all device IDs on the platform
+	if devicesErr := c.GetDeviceIds(platformID, &allDeviceIds); devicesErr != nil {
+		return fmt.Errorf("GetDevices failed: %v", devicesErr)
+	}
+
+	// then get full device details for each device ID in parallel
+	deviceList := make([]Device, len(allDeviceIds))
+	err := c.fanOut(len(allDeviceIds), func(i int) error {
+		deviceList[i].ID = allDeviceIds[i]
+		if deviceErr := c.GetDevice(&deviceList[i]); deviceErr != nil {
+			return fmt.Errorf("GetDevices failed: %v", deviceErr)
 		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	*devices = append(*devices, deviceList...)
 
+	return nil
+}
+
+// GetDevicesByName ... gets a list of devices on the given platform based on the given name
+func (c *APIClient) GetDevicesByName(platformID int, name string, devices *[]Device) error {
+	var allDevices []Device
+
+	if devicesErr := c.GetDevices(platformID, &allDevices); devicesErr != nil {
+		return fmt.Errorf("GetDevicesByName failed: %v", devicesErr)
+	}
+
+	for _, item := range allDevices {
 		// check if the resulting device is the one we're looking for by name
 		//   if it is, add it to our results list
-		if device.Name == name {
-			*devices = append(*devices, *device)
+		if item.Name == name {
+			*devices = append(*devices, item)
 		}
 	}
 
@@ -289,7 +358,7 @@ func (c *APIClient) UpdateGroup(group *Group) error {
 
 	var resp UpdateGroupResponseBlock
 
-	if err := c.Do("POST", apiPath, group, &resp); err != nil {
+	if err := c.Do("POST", apiPath, newGroupUpdate(group), &resp); err != nil {
 		return fmt.Errorf("Failed to update group: %s", err)
 	}
 
@@ -310,27 +379,57 @@ func (c *APIClient) DeleteGroup(group *Group) error {
 	return nil
 }
 
-// GetGroupsByName ... gets a list of groups based on the given name
-func (c *APIClient) GetGroupsByName(name string, groups *[]Group) error {
-	groupIdsAPIPath := "groups"
+// GetGroupIds ... gets the list of all group IDs & returns a ref to the list and any error
+// https://www.dotcom-monitor.com/wiki/knowledge-base/get-list-of-alert-groups/
+func (c *APIClient) GetGroupIds(groupIDs *[]int) error {
+	apiPath := "groups"
+
+	if err := c.Do("GET", apiPath, nil, &groupIDs); err != nil {
+		return fmt.Errorf("Failed to get all group IDs: %s", err)
+	}
+
+	return nil
+}
 
-	var groupsResp []int
+// GetGroups ... gets the list of groups & returns a ref to the groups and any error
+func (c *APIClient) GetGroups(groups *[]Group) error {
+	var allGroupIds []int
 
-	if err := c.Do("GET", groupIdsAPIPath, nil, &groupsResp); err != nil {
-		return fmt.Errorf("Failed to get group ID's by name: %s", err)
+	// first, get all group IDs
+	if groupsErr := c.GetGroupIds(&allGroupIds); groupsErr != nil {
+		return fmt.Errorf("GetGroups failed: %v", groupsErr)
 	}
 
-	for _, item := range groupsResp {
-		group := &Group{}
-		group.ID = item
-		if groupErr := c.GetGroup(group); groupErr != nil {
-			return fmt.Errorf("GetGroupsByName failed: %v", groupErr)
+	// then get full group details for each group ID in parallel
+	groupList := make([]Group, len(allGroupIds))
+	err := c.fanOut(len(allGroupIds), func(i int) error {
+		groupList[i].ID = allGroupIds[i]
+		if groupErr := c.GetGroup(&groupList[i]); groupErr != nil {
+			return fmt.Errorf("GetGroups failed: %v", groupErr)
 		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	*groups = append(*groups, groupList...)
+
+	return nil
+}
+
+// GetGroupsByName ... gets a list of groups based on the given name
+func (c *APIClient) GetGroupsByName(name string, groups *[]Group) error {
+	var allGroups []Group
+
+	if groupsErr := c.GetGroups(&allGroups); groupsErr != nil {
+		return fmt.Errorf("GetGroupsByName failed: %v", groupsErr)
+	}
 
+	for _, item := range allGroups {
 		// check if the resulting group is the one we're looking for by name
 		//   if it is, add it to our results list
-		if group.Name == name {
-			*groups = append(*groups, *group)
+		if item.Name == name {
+			*groups = append(*groups, item)
 		}
 	}
 
@@ -343,18 +442,9 @@ func (c *APIClient) GetGroupsByName(name string, groups *[]Group) error {
 
 // GetLocations ... gets the list of all locations available in the account by platform ID
 func (c *APIClient) GetLocations(platformID int, includeUnavailable bool, locations *[]Location) error {
-	// ensure platform is enabled
-	available, err := c.IsPlatformAvailable(platformID)
-	if err != nil {
-		return fmt.Errorf("Failed to check platform ID availability: %s", err)
-	} else if !available {
-		return fmt.Errorf("Platform ID %v is not available for this account", platformID)
-	}
-
-	apiPath := fmt.Sprintf("locations/%s", fmt.Sprint(platformID))
 	var resp []Location
 
-	if err := c.Do("GET", apiPath, nil, &resp); err != nil {
+	if err := c.GetLocationsWithDeleted(platformID, &resp); err != nil {
 		return fmt.Errorf("GetLocations failed: %s", err)
 	}
 
@@ -369,6 +459,25 @@ func (c *APIClient) GetLocations(platformID int, includeUnavailable bool, locati
 	return nil
 }
 
+// GetLocationsWithDeleted ... gets the list of all locations in the account by platform ID, including unavailable & deleted locations
+func (c *APIClient) GetLocationsWithDeleted(platformID int, locations *[]Location) error {
+	// ensure platform is enabled
+	available, err := c.IsPlatformAvailable(platformID)
+	if err != nil {
+		return fmt.Errorf("Failed to check platform ID availability: %s", err)
+	} else if !available {
+		return fmt.Errorf("Platform ID %v is not available for this account", platformID)
+	}
+
+	apiPath := fmt.Sprintf("locations/%s", fmt.Sprint(platformID))
+
+	if err := c.Do("GET", apiPath, nil, locations); err != nil {
+		return fmt.Errorf("GetLocationsWithDeleted failed: %s", err)
+	}
+
+	return nil
+}
+
 // GetLocation ... gets the location by id and platform ID
 func (c *APIClient) GetLocation(platformID int, locationID int, location *Location) error {
 	var locationsList []Location
@@ -476,9 +585,9 @@ func (c *APIClient) GetScheduler(scheduler *Scheduler) error {
 	return nil
 }
 
-// GetSchedulers ... gets all scheduler IDs & returns a ref to the schedulers and any error
+// GetSchedulerIds ... gets all scheduler IDs & returns a ref to the list and any error
 // https://www.dotcom-monitor.com/wiki/knowledge-base/get-list-of-schedulers/
-func (c *APIClient) GetSchedulers(schedulerIds *[]int) error {
+func (c *APIClient) GetSchedulerIds(schedulerIds *[]int) error {
 	apiPath := "schedulers"
 
 	if err := c.Do("GET", apiPath, nil, &schedulerIds); err != nil {
@@ -488,6 +597,32 @@ func (c *APIClient) GetSchedulers(schedulerIds *[]int) error {
 	return nil
 }
 
+// GetSchedulers ... gets the list of schedulers & returns a ref to the schedulers and any error
+func (c *APIClient) GetSchedulers(schedulers *[]Scheduler) error {
+	var allSchedulerIds []int
+
+	// first, get all scheduler IDs
+	if schedulersErr := c.GetSchedulerIds(&allSchedulerIds); schedulersErr != nil {
+		return fmt.Errorf("GetSchedulers failed: %v", schedulersErr)
+	}
+
+	// then get full scheduler details for each scheduler ID in parallel
+	schedulerList := make([]Scheduler, len(allSchedulerIds))
+	err := c.fanOut(len(allSchedulerIds), func(i int) error {
+		schedulerList[i].ID = allSchedulerIds[i]
+		if schedulerErr := c.GetScheduler(&schedulerList[i]); schedulerErr != nil {
+			return fmt.Errorf("GetSchedulers failed: %v", schedulerErr)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	*schedulers = append(*schedulers, schedulerList...)
+
+	return nil
+}
+
 // UpdateScheduler ... updates the scheduler & returns a ref to the scheduler and any error
 // https://www.dotcom-monitor.com/wiki/knowledge-base/edit-scheduler/
 func (c *APIClient) UpdateScheduler(scheduler *Scheduler) error {
@@ -495,7 +630,7 @@ func (c *APIClient) UpdateScheduler(scheduler *Scheduler) error {
 
 	var resp UpdateSchedulerResponseBlock
 
-	if err := c.Do("POST", apiPath, scheduler, &resp); err != nil {
+	if err := c.Do("POST", apiPath, newSchedulerUpdate(scheduler), &resp); err != nil {
 		return fmt.Errorf("Failed to update scheduler: %s", err)
 	}
 
@@ -518,25 +653,14 @@ func (c *APIClient) DeleteScheduler(scheduler *Scheduler) error {
 
 // GetSchedulersByName ... gets the schedulers by name
 func (c *APIClient) GetSchedulersByName(name string, schedulers *[]Scheduler) error {
-	var allSchedulerIds []int
-	var schedulersList []Scheduler
+	var allSchedulers []Scheduler
 
-	// first, get all scheduler IDs
-	if schedulersErr := c.GetSchedulers(&allSchedulerIds); schedulersErr != nil {
+	// first, get all schedulers
+	if schedulersErr := c.GetSchedulers(&allSchedulers); schedulersErr != nil {
 		return fmt.Errorf("GetSchedulersByName failed: %v", schedulersErr)
 	}
 
-	for _, item := range allSchedulerIds {
-		var scheduler Scheduler
-		scheduler.ID = item
-		// get full scheduler details for each scheduler ID
-		if schedulerErr := c.GetScheduler(&scheduler); schedulerErr != nil {
-			return fmt.Errorf("GetSchedulersByName failed: %v", schedulerErr)
-		}
-		schedulersList = append(schedulersList, scheduler)
-	}
-
-	for _, item := range schedulersList {
+	for _, item := range allSchedulers {
 		if item.Name == name {
 			*schedulers = append(*schedulers, item)
 		}
@@ -599,15 +723,19 @@ func (c *APIClient) GetFilters(filters *[]Filter) error {
 		return fmt.Errorf("GetFilters failed: %v", filtersErr)
 	}
 
-	for _, item := range allFilterIds {
-		var filter Filter
-		filter.ID = item
-		// then get full filter details for each filter ID
-		if filtersErr := c.GetFilter(&filter); filtersErr != nil {
+	// then get full filter details for each filter ID in parallel
+	filterList := make([]Filter, len(allFilterIds))
+	err := c.fanOut(len(allFilterIds), func(i int) error {
+		filterList[i].ID = allFilterIds[i]
+		if filtersErr := c.GetFilter(&filterList[i]); filtersErr != nil {
 			return fmt.Errorf("GetFilters failed: %v", filtersErr)
 		}
-		*filters = append(*filters, filter)
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+	*filters = append(*filters, filterList...)
 
 	return nil
 }
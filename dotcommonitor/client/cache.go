@@ -0,0 +1,115 @@
+package client
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL ... how long a cached list response is served without asking the API again
+const DefaultCacheTTL = 5 * time.Minute
+
+// cacheableEndpoints ... account-wide list endpoints whose GET responses may be cached, everything else always goes to the API
+var cacheableEndpoints = regexp.MustCompile(`^(platforms|locations/\d+|devices/\d+|device/\d+/tasks|groups|schedulers|filters)$`)
+
+// cacheInvalidations ... maps the first path segment of a create/update/delete endpoint to the cached endpoint prefixes it makes stale
+var cacheInvalidations = map[string][]string{
+	"tasks":      {"device/"},
+	"task":       {"device/"},
+	"devices":    {"devices/"},
+	"device":     {"devices/", "device/"},
+	"groups":     {"groups"},
+	"group":      {"groups"},
+	"schedulers": {"schedulers"},
+	"scheduler":  {"schedulers"},
+	"filters":    {"filters"},
+	"filter":     {"filters"},
+}
+
+// cacheEntry ... a cached response body along with its validator
+type cacheEntry struct {
+	body    []byte
+	etag    string
+	expires time.Time
+}
+
+// responseCache ... a TTL cache of raw response bodies keyed by endpoint, safe for concurrent use
+type responseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+}
+
+// newResponseCache ... creates an empty cache whose entries live for the given duration
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// get ... returns the entry for the endpoint, if any, and whether it is still fresh
+func (rc *responseCache) get(endpoint string) (*cacheEntry, bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	entry, ok := rc.entries[endpoint]
+	if !ok {
+		return nil, false
+	}
+
+	return &entry, time.Now().Before(entry.expires)
+}
+
+// set ... stores the response body for the endpoint
+func (rc *responseCache) set(endpoint string, body []byte, etag string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.entries[endpoint] = cacheEntry{
+		body:    body,
+		etag:    etag,
+		expires: time.Now().Add(rc.ttl),
+	}
+}
+
+// touch ... extends the life of an entry the API confirmed is unchanged
+func (rc *responseCache) touch(endpoint string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if entry, ok := rc.entries[endpoint]; ok {
+		entry.expires = time.Now().Add(rc.ttl)
+		rc.entries[endpoint] = entry
+	}
+}
+
+// invalidate ... drops every cached list a create/update/delete on the endpoint may have changed
+func (rc *responseCache) invalidate(endpoint string) {
+	segment := strings.SplitN(endpoint, "/", 2)[0]
+	prefixes, ok := cacheInvalidations[segment]
+	if !ok {
+		return
+	}
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for key := range rc.entries {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(key, prefix) {
+				delete(rc.entries, key)
+				break
+			}
+		}
+	}
+}
+
+// clear ... drops every cached entry
+func (rc *responseCache) clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.entries = make(map[string]cacheEntry)
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestCacheServesRepeatedListRequests(t *testing.T) {
+	fake := newFakeAPI(t, 3, 0)
+	api := fake.client(t, 1)
+	api.EnableCache(DefaultCacheTTL)
+
+	for i := 0; i < 3; i++ {
+		var ids []int
+		if err := api.GetDeviceIds(1, &ids); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if n := fake.count("GET", "devices/1"); n != 1 {
+		t.Fatalf("devices/1 requested %v times, want 1", n)
+	}
+}
+
+func TestCacheInvalidatedByUpdate(t *testing.T) {
+	fake := newFakeAPI(t, 3, 0)
+	api := fake.client(t, 1)
+	api.EnableCache(DefaultCacheTTL)
+
+	var ids []int
+	if err := api.GetDeviceIds(1, &ids); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := api.UpdateDevice(&Device{ID: 2, Name: "renamed"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := api.GetDeviceIds(1, &ids); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if n := fake.count("GET", "devices/1"); n != 2 {
+		t.Fatalf("devices/1 requested %v times, want the update to send the second request to the API", n)
+	}
+}
+
+func TestCacheNotInvalidatedByLogin(t *testing.T) {
+	fake := newFakeAPI(t, 3, 0)
+	api := fake.client(t, 1)
+	api.EnableCache(DefaultCacheTTL)
+
+	var platforms []Platform
+	if err := api.GetPlatforms(&platforms); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := api.Login("test-uid"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := api.GetPlatforms(&platforms); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if n := fake.count("GET", "platforms"); n != 1 {
+		t.Fatalf("platforms requested %v times, want 1", n)
+	}
+}
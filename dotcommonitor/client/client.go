@@ -8,6 +8,8 @@ import (
 	"io/ioutil"
 	"log"
 	"net/http"
+	"sync"
+	"time"
 )
 
 const (
@@ -26,6 +28,13 @@ type Client struct {
 	AuthCookie string
 	Transport  http.RoundTripper
 	verbose    bool
+
+	mu          sync.RWMutex  // guards LoggedIn & AuthCookie, requests may be made concurrently
+	limitMu     sync.Mutex    // guards nextRequest
+	minInterval time.Duration // minimum time between two requests, 0 means no limit
+	nextRequest time.Time
+
+	cache *responseCache // nil when response caching is disabled
 }
 
 // NewClient ... Creates a new Httpclient.
@@ -45,6 +54,58 @@ func (c *Client) Verbose(p bool) {
 	c.verbose = p
 }
 
+// RateLimit ... Limits the client to the given number of requests per second.
+//
+// A value of 0 or less removes the limit. The limit is shared by every
+// goroutine using the client, so concurrent lookups are throttled as a whole.
+func (c *Client) RateLimit(requestsPerSecond int) {
+	c.limitMu.Lock()
+	defer c.limitMu.Unlock()
+
+	if requestsPerSecond <= 0 {
+		c.minInterval = 0
+		return
+	}
+	c.minInterval = time.Second / time.Duration(requestsPerSecond)
+}
+
+// waitForRateLimit ... blocks until the next request is allowed by the rate limit
+func (c *Client) waitForRateLimit() {
+	c.limitMu.Lock()
+	if c.minInterval == 0 {
+		c.limitMu.Unlock()
+		return
+	}
+
+	now := time.Now()
+	wait := c.nextRequest.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	c.nextRequest = now.Add(wait + c.minInterval)
+	c.limitMu.Unlock()
+
+	time.Sleep(wait)
+}
+
+// EnableCache ... Enable caching of account-wide list responses for the given duration.
+//
+// Platforms, locations and the ID lists of devices, tasks, groups, schedulers
+// and filters are served from memory until they expire. Expired entries are
+// revalidated with their ETag when the API sent one. Creating, updating or
+// deleting an object drops the cached lists it affects.
+func (c *Client) EnableCache(ttl time.Duration) {
+	c.cache = newResponseCache(ttl)
+}
+
+// DisableCache ... Disable, and drop, the response cache.
+func (c *Client) DisableCache() {
+	if c.cache != nil {
+		c.cache.clear()
+	}
+	c.cache = nil
+}
+
 // Login ... Establishes a new session with the Dotcom-Monitor API.
 func (c *Client) Login(uid string) error {
 	var req = LoginBlock{
@@ -58,18 +119,24 @@ func (c *Client) Login(uid string) error {
 		return err
 	}
 
+	c.mu.Lock()
 	c.LoggedIn = resp.ResponseBlock.Success
+	c.mu.Unlock()
 	return nil
 }
 
 // Logout ... clears cookie
 func (c *Client) Logout() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	c.LoggedIn = false
 	c.AuthCookie = ""
 }
 
 // IsLoggedIn ... Determines if user is logged in
 func (c *Client) IsLoggedIn() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	return c.LoggedIn
 }
 
@@ -88,7 +155,13 @@ func (c *Client) newRequest(method, urlStr string, data []byte) (*http.Request,
 		r, err = http.NewRequest(method, urlStr, nil)
 	}
 
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
 	r.AddCookie(&http.Cookie{Name: AuthCookieName, Value: c.AuthCookie})
+	c.mu.RUnlock()
 	r.Header.Set("Content-Type", "application/json")
 
 	return r, err
@@ -105,6 +178,23 @@ func (c *Client) Do(method, endpoint string, requestData, responseData interface
 
 	var err error
 
+	// Serve account-wide lists from the cache while they are fresh
+	var cached *cacheEntry
+	cacheable := c.cache != nil && method == "GET" && cacheableEndpoints.MatchString(endpoint)
+	if cacheable {
+		entry, fresh := c.cache.get(endpoint)
+		if fresh {
+			if c.verbose {
+				log.Printf("[Dotcom-Monitor] serving %q from cache", endpoint)
+			}
+			if err := json.Unmarshal(entry.body, &responseData); err != nil {
+				return fmt.Errorf("error unmarshalling cached response: %v", err)
+			}
+			return nil
+		}
+		cached = entry
+	}
+
 	// Marshal the request data into a byte slice.
 	if c.verbose {
 		log.Println("[Dotcom-Monitor] marshaling request data")
@@ -127,28 +217,30 @@ func (c *Client) Do(method, endpoint string, requestData, responseData interface
 		return err
 	}
 
+	// Revalidate an expired cache entry rather than downloading it again
+	if cached != nil && cached.etag != "" {
+		req.Header.Set("If-None-Match", cached.etag)
+	}
+
 	if c.verbose {
 		log.Printf("Making %s request to %q", method, urlStr)
 	}
 
+	c.waitForRateLimit()
+
 	var resp *http.Response
 	resp, err = c.Transport.RoundTrip(req)
 
 	if err != nil {
-		if c.verbose {
-			respBody, err := ioutil.ReadAll(resp.Body)
-			if err != nil {
-				return err
-			}
-			log.Printf("%s", string(respBody))
-		}
 		return err
 	}
 
 	// Get cookies (session token)
 	for _, cookie := range resp.Cookies() {
 		if cookie.Name == AuthCookieName {
+			c.mu.Lock()
 			c.AuthCookie = cookie.Value
+			c.mu.Unlock()
 			break
 		}
 	}
@@ -157,6 +249,11 @@ func (c *Client) Do(method, endpoint string, requestData, responseData interface
 
 	switch resp.StatusCode {
 	case 200:
+		// Creates, updates & deletes change the account-wide lists
+		if c.cache != nil && method != "GET" && endpoint != "login" {
+			c.cache.invalidate(endpoint)
+		}
+
 		if resp.ContentLength == 0 {
 			// Zero-length content body?
 			log.Println("[Dotcom-Monitor] [WARNING] zero-length response body; skipping decoding of response")
@@ -172,8 +269,22 @@ func (c *Client) Do(method, endpoint string, requestData, responseData interface
 			return fmt.Errorf("error unmarshalling response: %v", err)
 		}
 
+		if cacheable {
+			c.cache.set(endpoint, text, resp.Header.Get("ETag"))
+		}
+
 		return nil
 
+	case 304:
+		// Cached list has not changed since it was stored
+		if cached != nil {
+			c.cache.touch(endpoint)
+			if err := json.Unmarshal(cached.body, &responseData); err != nil {
+				return fmt.Errorf("error unmarshalling cached response: %v", err)
+			}
+			return nil
+		}
+
 	case 401:
 		// https://wiki.dotcom-monitor.com/knowledge-base/authentication/
 		log.Println("[Dotcom-Monitor]: 401 - Unauthorized")
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAPI ... an in-memory Dotcom-Monitor API serving one platform of devices, each with tasks
+type fakeAPI struct {
+	*httptest.Server
+
+	Devices        int           // devices on platform 1, with IDs 1 to Devices
+	TasksPerDevice int           // tasks per device, with IDs deviceID*1000+1 onwards
+	Latency        time.Duration // added to every device & task detail request
+	FailDevice     int           // device ID whose details fail with a 500, 0 for none
+
+	mu       sync.Mutex
+	requests map[string]int // "<METHOD> <endpoint>" to the number of requests received
+}
+
+// newFakeAPI ... starts a fake API, stopped when the test ends
+func newFakeAPI(tb testing.TB, devices int, tasksPerDevice int) *fakeAPI {
+	f := &fakeAPI{
+		Devices:        devices,
+		TasksPerDevice: tasksPerDevice,
+		requests:       make(map[string]int),
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	tb.Cleanup(f.Close)
+	return f
+}
+
+// client ... returns a logged in client sending its requests to the fake API
+func (f *fakeAPI) client(tb testing.TB, workers int) *APIClient {
+	target, _ := url.Parse(f.URL)
+	api := NewAPIClient()
+	api.Transport = rewriteTransport{target}
+	api.SetFanOutWorkers(workers)
+	if err := api.Login("test-uid"); err != nil {
+		tb.Fatalf("login failed: %s", err)
+	}
+	return api
+}
+
+// count ... returns the number of requests received for the method & endpoint
+func (f *fakeAPI) count(method string, endpoint string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[method+" "+endpoint]
+}
+
+func (f *fakeAPI) serve(w http.ResponseWriter, r *http.Request) {
+	endpoint := strings.TrimPrefix(r.URL.Path, "/config_api_v1/")
+	f.mu.Lock()
+	f.requests[r.Method+" "+endpoint]++
+	f.mu.Unlock()
+
+	parts := strings.Split(endpoint, "/")
+	id := 0
+	if len(parts) > 1 {
+		id, _ = strconv.Atoi(parts[1])
+	}
+
+	var body interface{}
+	switch {
+	case r.Method == "POST" && endpoint == "login":
+		http.SetCookie(w, &http.Cookie{Name: AuthCookieName, Value: "session"})
+		body = ResponseBlock{Success: true}
+	case r.Method == "POST":
+		body = ResponseBlock{Success: true}
+	case endpoint == "platforms":
+		body = []Platform{{ID: 1, Name: "ServerView", Available: true}}
+	case endpoint == "devices/1":
+		body = idRange(1, f.Devices)
+	case parts[0] == "device" && len(parts) == 3 && parts[2] == "tasks":
+		body = idRange(id*1000+1, f.TasksPerDevice)
+	case parts[0] == "device" && len(parts) == 2:
+		time.Sleep(f.Latency)
+		if id == f.FailDevice {
+			http.Error(w, "device failure", http.StatusInternalServerError)
+			return
+		}
+		body = Device{ID: id, Name: "device-" + parts[1], PlatformID: 1}
+	case parts[0] == "task" && len(parts) == 2:
+		time.Sleep(f.Latency)
+		body = Task{ID: id, RequestType: "GET"}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+// idRange ... returns n consecutive IDs starting at first
+func idRange(first int, n int) []int {
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = first + i
+	}
+	return ids
+}
+
+// rewriteTransport ... sends requests for the API base URL to the fake API instead
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	r.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"sync"
+)
+
+// DefaultFanOutWorkers ... number of concurrent requests used when fetching the details of an ID list
+const DefaultFanOutWorkers = 4
+
+// SetFanOutWorkers ... sets the number of concurrent requests used when fetching the details of an ID list
+//
+// A value of 1 or less makes the lookups sequential.
+func (c *APIClient) SetFanOutWorkers(workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	c.fanOutWorkers = workers
+}
+
+// fanOut ... calls fn for every index in [0, n) on a bounded pool of workers
+//
+// Callers write their results by index, so the order of the results matches
+// the order of the input. Once a call fails no further indexes are handed out
+// and the first error is returned. Requests made by fn still go through
+// Client.Do and are therefore subject to the client rate limit.
+func (c *APIClient) fanOut(n int, fn func(i int) error) error {
+	workers := c.fanOutWorkers
+	if workers < 1 {
+		workers = DefaultFanOutWorkers
+	}
+	if workers > n {
+		workers = n
+	}
+
+	var (
+		mu       sync.Mutex
+		next     int
+		firstErr error
+		done     sync.WaitGroup
+	)
+
+	// nextIndex ... hands out the next index to work on, or -1 when there is no more work
+	nextIndex := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr != nil || next >= n {
+			return -1
+		}
+		i := next
+		next++
+		return i
+	}
+
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	for w := 0; w < workers; w++ {
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			for i := nextIndex(); i >= 0; i = nextIndex() {
+				if err := fn(i); err != nil {
+					fail(err)
+					return
+				}
+			}
+		}()
+	}
+	done.Wait()
+
+	return firstErr
+}
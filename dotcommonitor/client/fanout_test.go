@@ -0,0 +1,182 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFanOutKeepsInputOrder(t *testing.T) {
+	api := NewAPIClient()
+	api.SetFanOutWorkers(8)
+
+	results := make([]int, 50)
+	err := api.fanOut(len(results), func(i int) error {
+		time.Sleep(time.Duration((50-i)%7) * time.Millisecond) // finish out of order
+		results[i] = i * i
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for i, item := range results {
+		if item != i*i {
+			t.Fatalf("results[%v] = %v, want %v", i, item, i*i)
+		}
+	}
+}
+
+func TestFanOutReturnsFirstError(t *testing.T) {
+	api := NewAPIClient()
+	api.SetFanOutWorkers(1)
+
+	var calls int32
+	err := api.fanOut(10, func(i int) error {
+		atomic.AddInt32(&calls, 1)
+		if i == 3 || i == 5 {
+			return fmt.Errorf("failed on %v", i)
+		}
+		return nil
+	})
+
+	if err == nil || err.Error() != "failed on 3" {
+		t.Fatalf("error = %v, want the error of index 3", err)
+	}
+	if calls != 4 {
+		t.Fatalf("%v calls made, want no calls after the first error", calls)
+	}
+}
+
+func TestFanOutStopsHandingOutWorkAfterError(t *testing.T) {
+	api := NewAPIClient()
+	api.SetFanOutWorkers(4)
+
+	failure := errors.New("failed")
+	var calls int32
+	err := api.fanOut(1000, func(i int) error {
+		atomic.AddInt32(&calls, 1)
+		if i == 0 {
+			return failure
+		}
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+
+	if err != failure {
+		t.Fatalf("error = %v, want %v", err, failure)
+	}
+	if calls >= 1000 {
+		t.Fatalf("%v calls made, want the remaining indexes to be skipped", calls)
+	}
+}
+
+func TestGetDevicesKeepsIDListOrder(t *testing.T) {
+	fake := newFakeAPI(t, 40, 0)
+	fake.Latency = time.Millisecond
+	api := fake.client(t, 8)
+
+	var devices []Device
+	if err := api.GetDevices(1, &devices); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(devices) != 40 {
+		t.Fatalf("%v devices returned, want 40", len(devices))
+	}
+	for i, item := range devices {
+		if item.ID != i+1 {
+			t.Fatalf("devices[%v].ID = %v, want %v", i, item.ID, i+1)
+		}
+	}
+}
+
+func TestGetDevicesReturnsDeviceError(t *testing.T) {
+	fake := newFakeAPI(t, 40, 0)
+	fake.FailDevice = 17
+	api := fake.client(t, 8)
+
+	// a failed request logs the client out, so a concurrent request may report the closed client first
+	var devices []Device
+	err := api.GetDevices(1, &devices)
+	if err == nil || !strings.HasPrefix(err.Error(), "GetDevices failed") {
+		t.Fatalf("error = %v, want a GetDevices error", err)
+	}
+	if fake.count("GET", "device/17") != 1 {
+		t.Fatalf("device 17 requested %v times, want 1", fake.count("GET", "device/17"))
+	}
+	if len(devices) != 0 {
+		t.Fatalf("%v devices returned along with the error, want none", len(devices))
+	}
+}
+
+func TestRateLimitSpacesRequests(t *testing.T) {
+	fake := newFakeAPI(t, 5, 0)
+	api := fake.client(t, 5)
+	api.RateLimit(20) // one request every 50ms
+
+	start := time.Now()
+	var devices []Device
+	if err := api.GetDevices(1, &devices); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	elapsed := time.Since(start)
+
+	// platforms, the device ID list & 5 devices: 7 requests, the first one is not delayed
+	if want := 6 * 50 * time.Millisecond; elapsed < want {
+		t.Fatalf("7 requests took %v, want at least %v at 20 requests per second", elapsed, want)
+	}
+}
+
+func TestRateLimitZeroIsUnlimited(t *testing.T) {
+	api := NewAPIClient()
+	api.RateLimit(20)
+	api.RateLimit(0)
+
+	start := time.Now()
+	for i := 0; i < 100; i++ {
+		api.waitForRateLimit()
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Fatalf("100 unlimited requests waited %v", elapsed)
+	}
+}
+
+func BenchmarkGetDevices(b *testing.B) {
+	for _, workers := range []int{1, DefaultFanOutWorkers, 16} {
+		b.Run(fmt.Sprintf("workers=%v", workers), func(b *testing.B) {
+			fake := newFakeAPI(b, 50, 0)
+			fake.Latency = time.Millisecond
+			api := fake.client(b, workers)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				var devices []Device
+				if err := api.GetDevices(1, &devices); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetTasks(b *testing.B) {
+	for _, workers := range []int{1, DefaultFanOutWorkers, 16} {
+		b.Run(fmt.Sprintf("workers=%v", workers), func(b *testing.B) {
+			fake := newFakeAPI(b, 10, 10)
+			fake.Latency = time.Millisecond
+			api := fake.client(b, workers)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				var tasks []Task
+				if err := api.GetTasks(1, &tasks); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package client
 
+import (
+	"fmt"
+	"strings"
+)
+
 // Group ... struct for Group
 type Group struct {
 	ID          int         `json:"Id,omitempty"`
@@ -9,6 +14,29 @@ type Group struct {
 	AssignedTo  []int       `json:"Assigned_To,omitempty"`
 }
 
+// groupUpdate ... update payload for Group, sending an emptied address list as [] since the API leaves an omitted list unchanged
+type groupUpdate struct {
+	ID          int          `json:"Id,omitempty"`
+	Name        string       `json:"Name"`
+	SchedulerID int          `json:"Scheduler_Id,omitempty"`
+	Addresses   *[]Addresses `json:"Addresses,omitempty"`
+	AssignedTo  []int        `json:"Assigned_To,omitempty"`
+}
+
+// newGroupUpdate ... returns the update payload for the group
+func newGroupUpdate(group *Group) *groupUpdate {
+	update := &groupUpdate{
+		ID:          group.ID,
+		Name:        group.Name,
+		SchedulerID: group.SchedulerID,
+		AssignedTo:  group.AssignedTo,
+	}
+	if group.Addresses != nil {
+		update.Addresses = &group.Addresses
+	}
+	return update
+}
+
 // Addresses ... struct for delivery addresses
 type Addresses struct {
 	Type           string `json:"Type"`
@@ -26,6 +54,42 @@ type Addresses struct {
 	// Message        string `json:"Message,omitempty"`        // for Script?
 }
 
+// RedactedValue ... replaces secrets when addresses are printed, e.g. in logs
+const RedactedValue = "<redacted>"
+
+// String ... prints the address with its webhook, integration key & URL and community redacted
+func (a Addresses) String() string {
+	var fields []string
+	add := func(name, value string, secret bool) {
+		if value == "" {
+			return
+		}
+		if secret {
+			value = RedactedValue
+		}
+		fields = append(fields, fmt.Sprintf("%s:%s", name, value))
+	}
+
+	add("Type", a.Type, false)
+	if a.TemplateID != 0 {
+		add("TemplateID", fmt.Sprint(a.TemplateID), false)
+	}
+	add("Address", a.Address, false)
+	add("Number", a.Number, false)
+	add("Code", a.Code, false)
+	add("IntegrationKey", a.IntegrationKey, true)
+	add("IntegrationURL", a.IntegrationURL, true)
+	add("WebHook", a.WebHook, true)
+	add("Community", a.Community, true)
+	add("Host", a.Host, false)
+	if a.UserID != 0 {
+		add("UserID", fmt.Sprint(a.UserID), false)
+	}
+	add("Version", a.Version, false)
+
+	return "{" + strings.Join(fields, " ") + "}"
+}
+
 // CreateGroupResponseBlock ... struct for create group response
 type CreateGroupResponseBlock struct {
 	CreateResponseBlock
@@ -10,6 +10,47 @@ type Scheduler struct {
 	AssignedTo            AssignedTo         `json:"Assigned_To,omitempty"`
 }
 
+// schedulerUpdate ... update payload for Scheduler
+//
+// The API leaves out omitted lists unchanged, so a list emptied on the Scheduler
+// is sent as [] to clear it; a nil list is still omitted.
+type schedulerUpdate struct {
+	ID                    int                 `json:"Id,omitempty"`
+	Name                  string              `json:"Name"`
+	Description           string              `json:"Description,omitempty"`
+	WeeklyIntervals       *[]WeeklyInterval   `json:"Weekly_Intervals,omitempty"`
+	ExcludedTimeIntervals *[]DateTimeInterval `json:"Date_Time_Intervals,omitempty"`
+	AssignedTo            assignedToUpdate    `json:"Assigned_To"`
+}
+
+// assignedToUpdate ... update payload for AssignedTo, sending emptied lists as []
+type assignedToUpdate struct {
+	Devices *[]int `json:"Devices,omitempty"`
+	Groups  *[]int `json:"Notification_Groups,omitempty"`
+}
+
+// newSchedulerUpdate ... returns the update payload for the scheduler
+func newSchedulerUpdate(scheduler *Scheduler) *schedulerUpdate {
+	update := &schedulerUpdate{
+		ID:          scheduler.ID,
+		Name:        scheduler.Name,
+		Description: scheduler.Description,
+	}
+	if scheduler.AssignedTo.Devices != nil {
+		update.AssignedTo.Devices = &scheduler.AssignedTo.Devices
+	}
+	if scheduler.AssignedTo.Groups != nil {
+		update.AssignedTo.Groups = &scheduler.AssignedTo.Groups
+	}
+	if scheduler.WeeklyIntervals != nil {
+		update.WeeklyIntervals = &scheduler.WeeklyIntervals
+	}
+	if scheduler.ExcludedTimeIntervals != nil {
+		update.ExcludedTimeIntervals = &scheduler.ExcludedTimeIntervals
+	}
+	return update
+}
+
 // WeeklyInterval ... struct for repeating weekly interval
 type WeeklyInterval struct {
 	Days       []string `json:"Days,omitempty"`
@@ -10,15 +10,23 @@ import (
 
 // Config struct for data required to log into API
 type Config struct {
-	UID string
+	UID                   string
+	MaxConcurrentRequests int
+	RequestsPerSecond     int
+	DisableResponseCache  bool
 }
 
 // Client returns a new client.
 func (c *Config) Client() (*client.APIClient, error) {
-	client := client.NewAPIClient()
+	api := client.NewAPIClient()
+	api.SetFanOutWorkers(c.MaxConcurrentRequests)
+	api.RateLimit(c.RequestsPerSecond)
+	if !c.DisableResponseCache {
+		api.EnableCache(client.DefaultCacheTTL)
+	}
 
 	// API Login
-	err := client.Login(c.UID)
+	err := api.Login(c.UID)
 
 	if err != nil {
 		return nil, fmt.Errorf("Error logging into API: %s", err)
@@ -27,5 +35,5 @@ func (c *Config) Client() (*client.APIClient, error) {
 	log.Printf("[INFO] [Dotcom-Monitor] client configured for API key: %s", c.UID)
 	//log.Print(resp)
 
-	return client, nil
+	return api, nil
 }
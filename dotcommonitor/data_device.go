@@ -14,9 +14,40 @@ func dataDevice() *schema.Resource {
 		Read: dataDeviceRead,
 
 		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
 			"name": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
+			"name_prefix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 255),
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
+			"most_recent": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"id", "lowest_id"},
+			},
+			"lowest_id": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"id", "most_recent"},
 			},
 			"platform_id": {
 				Type:         schema.TypeInt,
@@ -24,6 +55,75 @@ func dataDevice() *schema.Resource {
 				Default:      1,                                         // ServerView
 				ValidateFunc: validation.IntInSlice([]int{1, 3, 7, 12}), // 1=ServerView, 3=MetricsView, 7=BrowserView, 12=WebView
 			},
+			"frequency": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"locations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"avoid_simultaneous_checks": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"alert_silence_min": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"false_positive_check": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"send_uptime_alert": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"status_description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"postpone": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"owner_device_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"filter_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"scheduler_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"number_of_tasks": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"package_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"notifications_groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"time_shift_min": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -36,32 +136,58 @@ func dataDeviceRead(d *schema.ResourceData, meta interface{}) error {
 	api := meta.(*client.APIClient)
 
 	platformID := d.Get("platform_id").(int)
-	name := d.Get("name").(string)
-	err := api.GetDevicesByName(platformID, name, &devices)
+	id := d.Get("id").(int)
+
+	// check which agrument was provided and make the appropriate API call
+	if id != 0 {
+		var device client.Device
+		device.ID = id
+		err := api.GetDevice(&device)
+		if err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to get device: %s", err)
+		}
+		if device.ID > 0 {
+			devices = append(devices, device)
+		}
+	} else {
+		var allDevices []client.Device
+		err := api.GetDevices(platformID, &allDevices)
+		if err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to get devices: %s", err)
+		}
+
+		query, match := dataSourceNameQuery(d)
+		for _, item := range allDevices {
+			if match(item.Name) {
+				devices = append(devices, item)
+			}
+		}
 
-	if err != nil {
-		return fmt.Errorf("[Dotcom-Monitor] Failed to get devices by name: %s", err)
+		// We cannot process a situation where there is more than one device with the same name
+		//  unless a tie-breaker was requested
+		if len(devices) > 1 {
+			// Get the list of ID's
+			ids := make([]int, len(devices))
+			for i, item := range devices {
+				ids[i] = item.ID
+			}
+
+			index := dataSourceTieBreak(d, ids)
+			if index < 0 {
+				return fmt.Errorf("[Dotcom-Monitor] Query returned %v devices from API for %s on platform ID %v - "+
+					"Device ID's returned: %v - "+
+					"Devices must be updated to be unique, or most_recent or lowest_id must be set, in order to use this data source", len(devices), query, platformID, ids)
+			}
+			devices = []client.Device{devices[index]}
+		}
 	}
 
-	// No devices found for the given name on the platform
+	// No devices found for the given query on the platform
 	if len(devices) < 1 {
 		return fmt.Errorf("[Dotcom-Monitor] Query did not return any devices from API")
 	}
 
-	// We cannot process a situation where there is more than one device with the same name
-	if len(devices) > 1 {
-		// Get the list of ID's
-		ids := make([]int, len(devices))
-		for i, item := range devices {
-			ids[i] = item.ID
-		}
-
-		return fmt.Errorf("[Dotcom-Monitor] Query returned %v devices from API for name %s on platform ID %v - "+
-			"Device ID's returned: %v - "+
-			"Devices must be updated to be unique in order to use this data source", len(devices), name, platformID, ids)
-	}
-
-	// If we get this far, we know we only got one device back from the API
+	// If we get this far, we know we only have one device
 	device := devices[0]
 	log.Printf("[Dotcom-Monitor] Single device found: %v", &device.Name)
 
@@ -77,6 +203,24 @@ func populateDeviceAttributes(d *schema.ResourceData, device client.Device) erro
 	// Set ID
 	strID := fmt.Sprint(device.ID)
 	d.SetId(strID)
+	d.Set("name", device.Name)
+	d.Set("platform_id", device.PlatformID)
+	d.Set("frequency", device.Frequency)
+	d.Set("locations", device.Locations)
+	d.Set("avoid_simultaneous_checks", device.AvoidSimultaneousChecks)
+	d.Set("alert_silence_min", device.AlertSilenceMin)
+	d.Set("false_positive_check", device.FalsePositiveCheck)
+	d.Set("send_uptime_alert", device.SendUptimeAlert)
+	d.Set("status_description", device.StatusDescription)
+	d.Set("postpone", device.Postpone)
+	d.Set("owner_device_id", device.OwnerDeviceID)
+	d.Set("filter_id", device.FilterID)
+	d.Set("scheduler_id", device.SchedulerID)
+	d.Set("number_of_tasks", device.NumberOfTasks)
+	d.Set("package_id", device.PackageID)
+	if device.Notifications != nil && device.Notifications.NotificationGroups != nil {
+		d.Set("notifications_groups", flattenNotificationsNotificationGroupList(&device.Notifications.NotificationGroups))
+	}
 
 	return nil
 }
@@ -0,0 +1,173 @@
+package dotcommonitor
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/hashstructure"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+func dataDevices() *schema.Resource {
+	return &schema.Resource{
+		Read: dataDevicesRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"platform_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,                                         // ServerView
+				ValidateFunc: validation.IntInSlice([]int{1, 3, 7, 12}), // 1=ServerView, 3=MetricsView, 7=BrowserView, 12=WebView
+			},
+			"scheduler_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"filter_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"devices": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"platform_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"frequency": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"locations": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+						},
+						"postpone": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"owner_device_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"filter_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"scheduler_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"number_of_tasks": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataDevicesRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var allDevices []client.Device
+	api := meta.(*client.APIClient)
+
+	platformID := d.Get("platform_id").(int)
+	err := api.GetDevices(platformID, &allDevices)
+
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get devices: %s", err)
+	}
+
+	// apply the optional filters
+	var devices []client.Device
+	for _, item := range allDevices {
+		if v, ok := d.GetOk("name_regex"); ok && !regexp.MustCompile(v.(string)).MatchString(item.Name) {
+			continue
+		}
+		if v, ok := d.GetOk("scheduler_id"); ok && item.SchedulerID != v.(int) {
+			continue
+		}
+		if v, ok := d.GetOk("filter_id"); ok && item.FilterID != v.(int) {
+			continue
+		}
+		devices = append(devices, item)
+	}
+	log.Printf("[Dotcom-Monitor] %v of %v devices matched on platform ID %v", len(devices), len(allDevices), platformID)
+
+	if err1 := populateDevicesAttributes(d, devices); err1 != nil {
+		log.Printf("[Dotcom-Monitor] Error setting devices attributes: %v", err1)
+	}
+
+	return nil
+}
+
+// populateDevicesAttributes ... fills in necessary schema attributes of the data source
+func populateDevicesAttributes(d *schema.ResourceData, devices []client.Device) error {
+	hash, err := hashstructure.Hash(devices, nil) // this may not generate a unique ID, but it is fine for data sources
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Error hashing device data to create ID: %s", err)
+	}
+	d.SetId(fmt.Sprint(hash))
+
+	ids := []int{}
+	names := []string{}
+	l := make([]map[string]interface{}, 0)
+	for _, item := range devices {
+		ids = append(ids, item.ID)
+		names = append(names, item.Name)
+
+		m := make(map[string]interface{})
+		m["id"] = item.ID
+		m["name"] = item.Name
+		m["platform_id"] = item.PlatformID
+		m["frequency"] = item.Frequency
+		m["locations"] = item.Locations
+		m["postpone"] = item.Postpone
+		m["owner_device_id"] = item.OwnerDeviceID
+		m["filter_id"] = item.FilterID
+		m["scheduler_id"] = item.SchedulerID
+		m["number_of_tasks"] = item.NumberOfTasks
+		l = append(l, m)
+	}
+	d.Set("ids", ids)
+	d.Set("names", names)
+	d.Set("devices", l)
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package dotcommonitor
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataErrorCodes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataErrorCodesRead,
+
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(errorCodeCatalogTypes(), true),
+			},
+			"codes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"codes_by_name": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"error_codes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"code": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataErrorCodesRead(d *schema.ResourceData, meta interface{}) error {
+	// the catalog is embedded in the provider, so no API call is needed
+	errorType := d.Get("type").(string)
+
+	catalog, ok := lookupErrorCodeCatalog(errorType)
+	if !ok {
+		return fmt.Errorf("[Dotcom-Monitor] No error code catalog for type %q, must be one of %v", errorType, errorCodeCatalogTypes())
+	}
+	log.Printf("[Dotcom-Monitor] %v known error codes for type %q", len(catalog.Codes), errorType)
+
+	d.SetId(strings.ToLower(errorType))
+
+	codes := []int{}
+	names := []string{}
+	codesByName := make(map[string]interface{})
+	l := make([]map[string]interface{}, 0)
+	for _, item := range catalog.Codes {
+		codes = append(codes, item.Code)
+		names = append(names, item.Name)
+		codesByName[item.Name] = item.Code
+
+		m := make(map[string]interface{})
+		m["code"] = item.Code
+		m["name"] = item.Name
+		m["description"] = item.Description
+		l = append(l, m)
+	}
+	d.Set("codes", codes)
+	d.Set("names", names)
+	d.Set("codes_by_name", codesByName)
+	d.Set("error_codes", l)
+
+	return nil
+}
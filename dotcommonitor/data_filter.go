@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
 )
 
@@ -16,12 +17,103 @@ func dataFilter() *schema.Resource {
 			"id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
-				ExactlyOneOf: []string{"id", "name"},
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
 			},
 			"name": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ExactlyOneOf: []string{"id", "name"},
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
+			"name_prefix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 255),
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
+			"most_recent": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"id", "lowest_id"},
+			},
+			"lowest_id": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"id", "most_recent"},
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"rules": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"num_locations": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"num_tasks": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"num_minutes": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"owner_device_down": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"ignore_errors": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"codes": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+						},
+						"ranges": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"from": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"to": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"assigned_device_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
 			},
 		},
 	}
@@ -35,7 +127,6 @@ func dataFilterRead(d *schema.ResourceData, meta interface{}) error {
 	api := meta.(*client.APIClient)
 
 	id := d.Get("id").(int)
-	name := d.Get("name").(string)
 
 	// check which agrument was provided and make the appropriate API call
 	if id != 0 {
@@ -45,14 +136,25 @@ func dataFilterRead(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("[Dotcom-Monitor] Failed to get filter: %s", err)
 		}
-		filters = append(filters, filter)
-	} else if name != "" {
-		err := api.GetFiltersByName(name, &filters)
+		if filter.ID > 0 {
+			filters = append(filters, filter)
+		}
+	} else {
+		var allFilters []client.Filter
+		err := api.GetFilters(&allFilters)
 		if err != nil {
-			return fmt.Errorf("[Dotcom-Monitor] Failed to get filters by name: %s", err)
+			return fmt.Errorf("[Dotcom-Monitor] Failed to get filters: %s", err)
+		}
+
+		query, match := dataSourceNameQuery(d)
+		for _, item := range allFilters {
+			if match(item.Name) {
+				filters = append(filters, item)
+			}
 		}
 
 		// We cannot process a situation where there is more than one filter with the same name
+		//  unless a tie-breaker was requested
 		if len(filters) > 1 {
 			// Get the list of ID's
 			ids := make([]int, len(filters))
@@ -60,18 +162,22 @@ func dataFilterRead(d *schema.ResourceData, meta interface{}) error {
 				ids[i] = item.ID
 			}
 
-			return fmt.Errorf("[Dotcom-Monitor] Query returned %v filters from API for name %s - "+
-				"Filter ID's returned: %v - "+
-				"Filter names must be unique in order to use this data source", len(filters), name, ids)
+			index := dataSourceTieBreak(d, ids)
+			if index < 0 {
+				return fmt.Errorf("[Dotcom-Monitor] Query returned %v filters from API for %s - "+
+					"Filter ID's returned: %v - "+
+					"Filter names must be unique, or most_recent or lowest_id must be set, in order to use this data source", len(filters), query, ids)
+			}
+			filters = []client.Filter{filters[index]}
 		}
 	}
 
-	// No filters found for this name
+	// No filters found for this query
 	if len(filters) < 1 {
 		return fmt.Errorf("[Dotcom-Monitor] Query did not return any matching filters from the API")
 	}
 
-	// If we get this far, we know we only got one filter back from the API
+	// If we get this far, we know we only have one filter
 	filter := filters[0]
 	log.Printf("[Dotcom-Monitor] Single filter found: %v", &filter.Name)
 
@@ -87,7 +193,10 @@ func populateFilterAttributes(d *schema.ResourceData, filter client.Filter) erro
 	strID := fmt.Sprint(filter.ID)
 	d.SetId(strID)
 	d.Set("name", filter.Name)
-	// TODO: Output more attributes?
+	d.Set("description", filter.Description)
+	d.Set("rules", flattenFilterRules(&filter.Rules))
+	d.Set("ignore_errors", flattenIgnoreErrors(&filter.Items))
+	d.Set("assigned_device_ids", filter.AssignedTo)
 
 	return nil
 }
@@ -0,0 +1,173 @@
+package dotcommonitor
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/hashstructure"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+func dataFilterChecks() *schema.Resource {
+	return &schema.Resource{
+		Read: dataFilterChecksRead,
+
+		Schema: map[string]*schema.Schema{
+			"filter_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"fail_on_issues": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ok": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"issues": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"filter_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"filter_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"device_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"device_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"reason": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// filterCheckIssue ... a filter that can never trigger on one of the devices it is assigned to
+type filterCheckIssue struct {
+	FilterID   int
+	FilterName string
+	DeviceID   int
+	DeviceName string
+	Reason     string
+}
+
+func dataFilterChecksRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var filters []client.Filter
+	api := meta.(*client.APIClient)
+
+	filterIDs := expandIntSet(d.Get("filter_ids").(*schema.Set))
+	if len(filterIDs) > 0 {
+		for _, filterID := range filterIDs {
+			filter := client.Filter{ID: filterID}
+			if err := api.GetFilter(&filter); err != nil {
+				return fmt.Errorf("[Dotcom-Monitor] Failed to get filter: %s", err)
+			}
+			if !(filter.ID > 0) {
+				return fmt.Errorf("[Dotcom-Monitor] Filter ID %v does not exist", filterID)
+			}
+			filters = append(filters, filter)
+		}
+	} else if err := api.GetFilters(&filters); err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get filters: %s", err)
+	}
+
+	issues, err := checkFilterAssignments(api, filters)
+	if err != nil {
+		return err
+	}
+	log.Printf("[Dotcom-Monitor] %v filters checked, %v issues found", len(filters), len(issues))
+
+	if d.Get("fail_on_issues").(bool) && len(issues) > 0 {
+		var reasons []string
+		for _, item := range issues {
+			reasons = append(reasons, fmt.Sprintf("filter %q (ID %v) on device %q (ID %v): %s", item.FilterName, item.FilterID, item.DeviceName, item.DeviceID, item.Reason))
+		}
+		return fmt.Errorf("[Dotcom-Monitor] Filters that can never trigger: %s", strings.Join(reasons, "; "))
+	}
+
+	if err1 := populateFilterChecksAttributes(d, issues); err1 != nil {
+		log.Printf("[Dotcom-Monitor] Error setting filter checks attributes: %v", err1)
+	}
+
+	return nil
+}
+
+// checkFilterAssignments ... compares the rules of each filter with the locations & tasks of the devices it is assigned to
+func checkFilterAssignments(api *client.APIClient, filters []client.Filter) ([]filterCheckIssue, error) {
+	issues := []filterCheckIssue{}
+
+	for _, filter := range filters {
+		for _, deviceID := range filter.AssignedTo {
+			device := &client.Device{ID: deviceID}
+			if err := api.GetDevice(device); err != nil {
+				return nil, fmt.Errorf("[Dotcom-Monitor] Failed to get device: %s", err)
+			}
+			if !(device.ID > 0) {
+				continue // assignment to a device that has been deleted since
+			}
+
+			var taskIDs []int
+			if err := api.GetTaskIdsByDevice(device, &taskIDs); err != nil {
+				return nil, fmt.Errorf("[Dotcom-Monitor] Failed to get device tasks: %s", err)
+			}
+
+			for _, reason := range checkFilterRules(filter.Rules, len(device.Locations), len(taskIDs)) {
+				issues = append(issues, filterCheckIssue{
+					FilterID:   filter.ID,
+					FilterName: filter.Name,
+					DeviceID:   device.ID,
+					DeviceName: device.Name,
+					Reason:     reason,
+				})
+			}
+		}
+	}
+
+	return issues, nil
+}
+
+// populateFilterChecksAttributes ... fills in necessary schema attributes of the data source
+func populateFilterChecksAttributes(d *schema.ResourceData, issues []filterCheckIssue) error {
+	hash, err := hashstructure.Hash(issues, nil) // this may not generate a unique ID, but it is fine for data sources
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Error hashing filter check data to create ID: %s", err)
+	}
+	d.SetId(fmt.Sprint(hash))
+
+	l := make([]map[string]interface{}, 0)
+	for _, item := range issues {
+		m := make(map[string]interface{})
+		m["filter_id"] = item.FilterID
+		m["filter_name"] = item.FilterName
+		m["device_id"] = item.DeviceID
+		m["device_name"] = item.DeviceName
+		m["reason"] = item.Reason
+		l = append(l, m)
+	}
+	d.Set("ok", len(issues) == 0)
+	d.Set("issues", l)
+
+	return nil
+}
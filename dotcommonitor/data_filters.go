@@ -0,0 +1,128 @@
+package dotcommonitor
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/hashstructure"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+func dataFilters() *schema.Resource {
+	return &schema.Resource{
+		Read: dataFiltersRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"device_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"filters": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"assigned_device_ids": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataFiltersRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var allFilters []client.Filter
+	api := meta.(*client.APIClient)
+
+	err := api.GetFilters(&allFilters)
+
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get filters: %s", err)
+	}
+
+	// apply the optional filters
+	var filters []client.Filter
+	for _, item := range allFilters {
+		if v, ok := d.GetOk("name_regex"); ok && !regexp.MustCompile(v.(string)).MatchString(item.Name) {
+			continue
+		}
+		if v, ok := d.GetOk("device_id"); ok && !intInList(item.AssignedTo, v.(int)) {
+			continue
+		}
+		filters = append(filters, item)
+	}
+	log.Printf("[Dotcom-Monitor] %v of %v filters matched", len(filters), len(allFilters))
+
+	if err1 := populateFiltersAttributes(d, filters); err1 != nil {
+		log.Printf("[Dotcom-Monitor] Error setting filters attributes: %v", err1)
+	}
+
+	return nil
+}
+
+// populateFiltersAttributes ... fills in necessary schema attributes of the data source
+func populateFiltersAttributes(d *schema.ResourceData, filters []client.Filter) error {
+	hash, err := hashstructure.Hash(filters, nil) // this may not generate a unique ID, but it is fine for data sources
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Error hashing filter data to create ID: %s", err)
+	}
+	d.SetId(fmt.Sprint(hash))
+
+	ids := []int{}
+	names := []string{}
+	l := make([]map[string]interface{}, 0)
+	for _, item := range filters {
+		ids = append(ids, item.ID)
+		names = append(names, item.Name)
+
+		m := make(map[string]interface{})
+		m["id"] = item.ID
+		m["name"] = item.Name
+		m["description"] = item.Description
+		m["assigned_device_ids"] = item.AssignedTo
+		l = append(l, m)
+	}
+	d.Set("ids", ids)
+	d.Set("names", names)
+	d.Set("filters", l)
+
+	return nil
+}
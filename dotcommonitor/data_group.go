@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
 )
 
@@ -13,9 +14,89 @@ func dataGroup() *schema.Resource {
 		Read: dataGroupRead,
 
 		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
 			"name": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
+			"name_prefix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 255),
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
+			"most_recent": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"id", "lowest_id"},
+			},
+			"lowest_id": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"id", "most_recent"},
+			},
+			"scheduler_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"addresses": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"template_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"number": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"code": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"assigned_to": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
 			},
 		},
 	}
@@ -28,32 +109,58 @@ func dataGroupRead(d *schema.ResourceData, meta interface{}) error {
 	var groups []client.Group
 	api := meta.(*client.APIClient)
 
-	name := d.Get("name").(string)
-	err := api.GetGroupsByName(name, &groups)
+	id := d.Get("id").(int)
 
-	if err != nil {
-		return fmt.Errorf("[Dotcom-Monitor] Failed to get groups by name: %s", err)
-	}
+	// check which agrument was provided and make the appropriate API call
+	if id != 0 {
+		var group client.Group
+		group.ID = id
+		err := api.GetGroup(&group)
+		if err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to get group: %s", err)
+		}
+		if group.ID > 0 {
+			groups = append(groups, group)
+		}
+	} else {
+		var allGroups []client.Group
+		err := api.GetGroups(&allGroups)
+		if err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to get groups: %s", err)
+		}
 
-	// No groups found for the given name on the platform
-	if len(groups) < 1 {
-		return fmt.Errorf("[Dotcom-Monitor] Query did not return any groups from API")
-	}
+		query, match := dataSourceNameQuery(d)
+		for _, item := range allGroups {
+			if match(item.Name) {
+				groups = append(groups, item)
+			}
+		}
 
-	// We cannot process a situation where there is more than one group with the same name
-	if len(groups) > 1 {
-		// Get the list of ID's
-		ids := make([]int, len(groups))
-		for i, item := range groups {
-			ids[i] = item.ID
+		// We cannot process a situation where there is more than one group with the same name
+		//  unless a tie-breaker was requested
+		if len(groups) > 1 {
+			// Get the list of ID's
+			ids := make([]int, len(groups))
+			for i, item := range groups {
+				ids[i] = item.ID
+			}
+
+			index := dataSourceTieBreak(d, ids)
+			if index < 0 {
+				return fmt.Errorf("[Dotcom-Monitor] Query returned %v groups from API for %s - "+
+					"Group ID's returned: %v - "+
+					"Groups must be updated to be unique, or most_recent or lowest_id must be set, in order to use this data source", len(groups), query, ids)
+			}
+			groups = []client.Group{groups[index]}
 		}
+	}
 
-		return fmt.Errorf("[Dotcom-Monitor] Query returned %v groups from API for name %s - "+
-			"Group ID's returned: %v - "+
-			"Groups must be updated to be unique in order to use this data source", len(groups), name, ids)
+	// No groups found for the given query
+	if len(groups) < 1 {
+		return fmt.Errorf("[Dotcom-Monitor] Query did not return any groups from API")
 	}
 
-	// If we get this far, we know we only got one group back from the API
+	// If we get this far, we know we only have one group
 	group := groups[0]
 	log.Printf("[Dotcom-Monitor] Single group found: %v", &group.Name)
 
@@ -69,6 +176,23 @@ func populateGroupAttributes(d *schema.ResourceData, group client.Group) error {
 	// Set ID
 	strID := fmt.Sprint(group.ID)
 	d.SetId(strID)
+	d.Set("name", group.Name)
+	d.Set("scheduler_id", group.SchedulerID)
+	d.Set("addresses", flattenGroupAddressesWithoutSecrets(&group.Addresses))
+	d.Set("assigned_to", group.AssignedTo)
 
 	return nil
 }
+
+// flattenGroupAddressesWithoutSecrets ... flattens group address objects, leaving out the secret fields
+func flattenGroupAddressesWithoutSecrets(addresses *[]client.Addresses) []map[string]interface{} {
+	l := flattenGroupAddresses(addresses)
+
+	for _, m := range l {
+		for _, field := range groupAddressSecretFields {
+			delete(m, field)
+		}
+	}
+
+	return l
+}
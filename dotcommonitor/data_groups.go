@@ -0,0 +1,128 @@
+package dotcommonitor
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/hashstructure"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+func dataGroups() *schema.Resource {
+	return &schema.Resource{
+		Read: dataGroupsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"scheduler_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"scheduler_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"assigned_to": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataGroupsRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var allGroups []client.Group
+	api := meta.(*client.APIClient)
+
+	err := api.GetGroups(&allGroups)
+
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get groups: %s", err)
+	}
+
+	// apply the optional filters
+	var groups []client.Group
+	for _, item := range allGroups {
+		if v, ok := d.GetOk("name_regex"); ok && !regexp.MustCompile(v.(string)).MatchString(item.Name) {
+			continue
+		}
+		if v, ok := d.GetOk("scheduler_id"); ok && item.SchedulerID != v.(int) {
+			continue
+		}
+		groups = append(groups, item)
+	}
+	log.Printf("[Dotcom-Monitor] %v of %v groups matched", len(groups), len(allGroups))
+
+	if err1 := populateGroupsAttributes(d, groups); err1 != nil {
+		log.Printf("[Dotcom-Monitor] Error setting groups attributes: %v", err1)
+	}
+
+	return nil
+}
+
+// populateGroupsAttributes ... fills in necessary schema attributes of the data source
+func populateGroupsAttributes(d *schema.ResourceData, groups []client.Group) error {
+	hash, err := hashstructure.Hash(groups, nil) // this may not generate a unique ID, but it is fine for data sources
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Error hashing group data to create ID: %s", err)
+	}
+	d.SetId(fmt.Sprint(hash))
+
+	ids := []int{}
+	names := []string{}
+	l := make([]map[string]interface{}, 0)
+	for _, item := range groups {
+		ids = append(ids, item.ID)
+		names = append(names, item.Name)
+
+		m := make(map[string]interface{})
+		m["id"] = item.ID
+		m["name"] = item.Name
+		m["scheduler_id"] = item.SchedulerID
+		m["assigned_to"] = item.AssignedTo
+		l = append(l, m)
+	}
+	d.Set("ids", ids)
+	d.Set("names", names)
+	d.Set("groups", l)
+
+	return nil
+}
@@ -3,6 +3,7 @@ package dotcommonitor
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -31,17 +32,24 @@ func dataLocations() *schema.Resource {
 				ExactlyOneOf: []string{"all_locations", "all_public_locations", "all_private_locations", "ids", "names"},
 			},
 			"ids": {
-				Type:         schema.TypeSet,
+				Type:         schema.TypeList,
 				Optional:     true,
-				Elem: 		  &schema.Schema{Type: schema.TypeInt},
+				Computed:     true,
+				Elem:         &schema.Schema{Type: schema.TypeInt},
 				ExactlyOneOf: []string{"all_locations", "all_public_locations", "all_private_locations", "ids", "names"},
 			},
 			"names": {
-				Type:         schema.TypeSet,
+				Type:         schema.TypeList,
 				Optional:     true,
-				Elem: 		  &schema.Schema{Type: schema.TypeString},
+				Computed:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
 				ExactlyOneOf: []string{"all_locations", "all_public_locations", "all_private_locations", "ids", "names"},
 			},
+			"ids_by_name": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
 			"platform_id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -58,6 +66,103 @@ func dataLocations() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			"regions": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(locationRegions, false),
+				},
+			},
+			"countries": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString, ValidateFunc: validateCountryCode},
+				ConflictsWith: []string{"exclude_countries"},
+			},
+			"exclude_countries": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString, ValidateFunc: validateCountryCode},
+				ConflictsWith: []string{"countries"},
+			},
+			"exclude_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"location_count": { // "count" is reserved for the Terraform meta-argument
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"location_metadata": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"region": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice(locationRegions, false),
+						},
+						"country": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateCountryCode,
+						},
+						"restrictive": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+						},
+					},
+				},
+			},
+			"locations": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"country": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"private": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"available": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"restrictive": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -72,11 +177,15 @@ func dataLocationsRead(d *schema.ResourceData, meta interface{}) error {
 	all := d.Get("all_locations").(bool)
 	allPublic := d.Get("all_public_locations").(bool)
 	allPrivate := d.Get("all_private_locations").(bool)
-	ids := expandIntSet(d.Get("ids").(*schema.Set))
-	names := expandStringSet(d.Get("names").(*schema.Set))
+	ids := convertInterfaceListToIntList(d.Get("ids").([]interface{}))
+	names := convertInterfaceListToStringList(d.Get("names").([]interface{}))
 	platformID := d.Get("platform_id").(int)
 	includeUnavailable := d.Get("include_unavailable").(bool)
 	includeRestrictive := d.Get("include_restrictive").(bool)
+	overrides, err := expandLocationMetadata(d.Get("location_metadata").([]interface{}))
+	if err != nil {
+		return err
+	}
 
 	// check which agrument was provided and make the appropriate API call
 	if (all) {
@@ -101,12 +210,12 @@ func dataLocationsRead(d *schema.ResourceData, meta interface{}) error {
 			return fmt.Errorf("[Dotcom-Monitor] Failed to get all locations: %s", err)
 		}
 
-		// check all locations to ensure ID is valid
-		for _, item := range allTemp {
-			if (!locationListContainsLocationID(allTemp, item.ID)) {
-				return fmt.Errorf("[Dotcom-Monitor] No valid location return from API for ID: %v", item.ID)
-			}
-			locations = append(locations, item)
+		// select exactly the requested locations, in the requested order
+		var unknownIDs []int
+		locations, unknownIDs = selectLocationsByID(allTemp, ids)
+		if len(unknownIDs) > 0 {
+			return fmt.Errorf("[Dotcom-Monitor] No valid locations returned from API for ID's: %v - "+
+				"Locations that are not available are only returned with include_unavailable", unknownIDs)
 		}
 	} else if (len(names) > 0) {
 		var allTemp []client.Location
@@ -115,12 +224,15 @@ func dataLocationsRead(d *schema.ResourceData, meta interface{}) error {
 			return fmt.Errorf("[Dotcom-Monitor] Failed to get all locations: %s", err)
 		}
 
-		// check all locations to ensure name is valid
-		for _, item := range allTemp {
-			if (!locationListContainsLocationName(allTemp, item.Name)) {
-				return fmt.Errorf("[Dotcom-Monitor] No valid location return from API for name: %v", item.ID)
-			}
-			locations = append(locations, item)
+		// select exactly the requested locations, in the requested order
+		var unknownNames []string
+		locations, unknownNames, err = selectLocationsByName(allTemp, names)
+		if err != nil {
+			return err
+		}
+		if len(unknownNames) > 0 {
+			return fmt.Errorf("[Dotcom-Monitor] No valid locations returned from API for names: %q - "+
+				"Locations that are not available are only returned with include_unavailable", unknownNames)
 		}
 	}
 
@@ -131,10 +243,28 @@ func dataLocationsRead(d *schema.ResourceData, meta interface{}) error {
 
 	// remove restrictive locations if requested
 	if (!includeRestrictive) {
-		locations = removeRestrictiveLocations(locations)
+		locations = removeRestrictiveLocations(locations, overrides)
+	}
+
+	locations = filterLocationsByMetadata(locations, overrides,
+		expandIntSet(d.Get("exclude_ids").(*schema.Set)),
+		expandStringSet(d.Get("regions").(*schema.Set)),
+		expandStringSet(d.Get("countries").(*schema.Set)),
+		expandStringSet(d.Get("exclude_countries").(*schema.Set)))
+
+	// pick the requested number of locations, spread across regions
+	if count, ok := d.GetOk("location_count"); ok {
+		if len(locations) < count.(int) {
+			return fmt.Errorf("[Dotcom-Monitor] Query returned %v locations matching the filters, %v were requested", len(locations), count.(int))
+		}
+		locations = spreadLocations(locations, overrides, count.(int))
+	}
+
+	if len(locations) < 1 {
+		return fmt.Errorf("[Dotcom-Monitor] No locations match the filters")
 	}
 
-	if err1 := populateLocationsAttributes(d, locations); err1 != nil {
+	if err1 := populateLocationsAttributes(d, locations, overrides); err1 != nil {
 		log.Printf("[Dotcom-Monitor] Error setting location attributes: %v", err1)
 	}
 
@@ -142,7 +272,7 @@ func dataLocationsRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 // populateLocationAttributes ... fills in necessary schema attributes of the data source
-func populateLocationsAttributes(d *schema.ResourceData, locations []client.Location) error {
+func populateLocationsAttributes(d *schema.ResourceData, locations []client.Location, overrides []locationInfo) error {
 	hash, err := hashstructure.Hash(locations, nil)  // this may not generate a unique ID, but it is fine for data sources
 	if err != nil {
 		panic("[Dotcom-Monitor] Error hashing location data to create ID")
@@ -153,12 +283,16 @@ func populateLocationsAttributes(d *schema.ResourceData, locations []client.Loca
 	// fill ids and names
 	ids := []int{}
 	names := []string{}
+	idsByName := make(map[string]interface{})
 	for _, item := range(locations) {
 		ids = append(ids, item.ID)
 		names = append(names, item.Name)
+		idsByName[item.Name] = item.ID
 	}
 	d.Set("ids", ids)
 	d.Set("names", names)
+	d.Set("ids_by_name", idsByName)
+	d.Set("locations", flattenLocations(locations, overrides))
 
 	return nil
 }
@@ -178,34 +312,148 @@ func locationListContainsLocationID(locations []client.Location, id int) bool {
     return false
 }
 
-// locationListContainsLocationName .. checks if provided location name is valid in the list of locations
-func locationListContainsLocationName(locations []client.Location, name string) bool {
-    for _, item := range locations {
-        if item.Name == name {
-            return true
-        }
-    }
-    return false
+// selectLocationsByID ... picks the locations with the given ID's in the order requested, skipping repeated ID's,
+//  and returns the ID's that are not in the list of locations
+func selectLocationsByID(locations []client.Location, ids []int) ([]client.Location, []int) {
+	byID := make(map[int]client.Location)
+	for _, item := range locations {
+		byID[item.ID] = item
+	}
+
+	selected := []client.Location{}
+	unknown := []int{}
+	seen := make(map[int]bool)
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if item, ok := byID[id]; ok {
+			selected = append(selected, item)
+		} else {
+			unknown = append(unknown, id)
+		}
+	}
+	return selected, unknown
+}
+
+// selectLocationsByName ... picks the locations with the given names in the order requested, skipping repeated names,
+//  and returns the names that are not in the list of locations
+//  Names shared by more than one location are ambiguous and return an error
+func selectLocationsByName(locations []client.Location, names []string) ([]client.Location, []string, error) {
+	byName := make(map[string][]client.Location)
+	for _, item := range locations {
+		byName[item.Name] = append(byName[item.Name], item)
+	}
+
+	selected := []client.Location{}
+	unknown := []string{}
+	seen := make(map[string]bool)
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		matches := byName[name]
+		switch len(matches) {
+		case 0:
+			unknown = append(unknown, name)
+		case 1:
+			selected = append(selected, matches[0])
+		default:
+			ids := make([]int, len(matches))
+			for i, item := range matches {
+				ids[i] = item.ID
+			}
+			return nil, nil, fmt.Errorf("[Dotcom-Monitor] Location name %q matches %v locations - Location ID's returned: %v - "+
+				"Select these locations with ids instead", name, len(matches), ids)
+		}
+	}
+	return selected, unknown, nil
 }
 
 // removeRestrictiveLocations .. removes any locations that may be considered restrictive by
 //  country-wide firewalls, government regulations, restrictions, etc.
-//  This list can be updated as appropriate. 
-func removeRestrictiveLocations(locations []client.Location) []client.Location {
-	var restrictiveLocationIds = []int {11, 72, 184, 445, 446, 447, 448}
-	// 11  = Hong Kong
-	// 72  = Shanghai
-	// 184 = Beijing
-	// 445 = Chengdu
-	// 446 = Guangzhou
-	// 447 = Qingdao
-	// 448 = Shenzhen
-
+//  Restrictive locations are flagged in the location metadata table.
+func removeRestrictiveLocations(locations []client.Location, overrides []locationInfo) []client.Location {
 	var trimmedLocationList []client.Location
 	for _, item := range locations { // iterate selected locations
-		if !intInList(restrictiveLocationIds, item.ID) && !locationListContainsLocationID(trimmedLocationList, item.ID) {
+		if !isRestrictiveLocation(item, overrides) && !locationListContainsLocationID(trimmedLocationList, item.ID) {
 			trimmedLocationList = append(trimmedLocationList, item)
 		}
 	}
 	return trimmedLocationList
 }
+
+// filterLocationsByMetadata ... removes excluded locations, and keeps only locations in the given regions & countries when set
+//  Locations without metadata are kept unless regions or countries are set
+func filterLocationsByMetadata(locations []client.Location, overrides []locationInfo, excludeIDs []int, regions []string, countries []string, excludeCountries []string) []client.Location {
+	toSet := func(l []string) map[string]bool {
+		m := make(map[string]bool)
+		for _, item := range l {
+			m[strings.ToUpper(item)] = true
+		}
+		return m
+	}
+	regionSet, countrySet, excludeCountrySet := toSet(regions), toSet(countries), toSet(excludeCountries)
+	excludeIDSet := make(map[int]bool)
+	for _, id := range excludeIDs {
+		excludeIDSet[id] = true
+	}
+
+	filtered := []client.Location{}
+	for _, item := range locations {
+		info, _ := lookupLocationInfo(item, overrides)
+		if excludeIDSet[item.ID] || excludeCountrySet[info.Country] {
+			continue
+		}
+		if len(regionSet) > 0 && !regionSet[info.Region] {
+			continue
+		}
+		if len(countrySet) > 0 && !countrySet[info.Country] {
+			continue
+		}
+		filtered = append(filtered, item)
+	}
+	return filtered
+}
+
+// expandLocationMetadata ... constructs the location metadata overrides from the location_metadata blocks in the TF configuration
+func expandLocationMetadata(blocks []interface{}) ([]locationInfo, error) {
+	overrides := []locationInfo{}
+	for _, item := range blocks {
+		schemaMap := item.(map[string]interface{})
+		info := locationInfo{
+			ID:          schemaMap["id"].(int),
+			Name:        schemaMap["name"].(string),
+			Region:      schemaMap["region"].(string),
+			Country:     schemaMap["country"].(string),
+			Restrictive: schemaMap["restrictive"].(bool),
+		}
+		if info.ID == 0 && info.Name == "" {
+			return nil, fmt.Errorf("[Dotcom-Monitor] location_metadata blocks must set id or name")
+		}
+		overrides = append(overrides, info)
+	}
+	return overrides, nil
+}
+
+// flattenLocations ... flattens location objects & their metadata to generic interface for state
+func flattenLocations(locations []client.Location, overrides []locationInfo) []map[string]interface{} {
+	l := make([]map[string]interface{}, 0)
+	for _, item := range locations {
+		info, _ := lookupLocationInfo(item, overrides)
+		m := make(map[string]interface{})
+		m["id"] = item.ID
+		m["name"] = item.Name
+		m["region"] = info.Region
+		m["country"] = info.Country
+		m["private"] = item.IsPrivate
+		m["available"] = item.Available
+		m["restrictive"] = info.Restrictive
+		l = append(l, m)
+	}
+	return l
+}
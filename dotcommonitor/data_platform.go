@@ -0,0 +1,136 @@
+package dotcommonitor
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+func dataPlatform() *schema.Resource {
+	return &schema.Resource{
+		Read: dataPlatformRead,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name"},
+			},
+			"available": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"packages": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"package_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+func dataPlatformRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var allPlatforms []client.Platform
+	var platforms []client.Platform
+	api := meta.(*client.APIClient)
+
+	err := api.GetPlatforms(&allPlatforms)
+
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get platforms: %s", err)
+	}
+
+	id := d.Get("id").(int)
+	name := d.Get("name").(string)
+
+	// check which agrument was provided
+	for _, item := range allPlatforms {
+		if (id != 0 && item.ID == id) || (id == 0 && item.Name == name) {
+			platforms = append(platforms, item)
+		}
+	}
+
+	// No platforms found for the given query
+	if len(platforms) < 1 {
+		return fmt.Errorf("[Dotcom-Monitor] Query did not return any platforms from API")
+	}
+
+	// If we get this far, we know we only got one platform back from the API
+	platform := platforms[0]
+	log.Printf("[Dotcom-Monitor] Single platform found: %v", &platform.Name)
+
+	if err1 := populatePlatformAttributes(d, platform); err1 != nil {
+		log.Printf("[Dotcom-Monitor] Error setting platform attributes: %v", err1)
+	}
+
+	return nil
+}
+
+// populatePlatformAttributes ... fills in necessary schema attributes of the data source
+func populatePlatformAttributes(d *schema.ResourceData, platform client.Platform) error {
+	strID := fmt.Sprint(platform.ID)
+	d.SetId(strID)
+	d.Set("name", platform.Name)
+	d.Set("available", platform.Available)
+	d.Set("packages", flattenPlatformPackages(&platform.Packages))
+	d.Set("package_ids", flattenPlatformPackageIds(&platform.Packages))
+
+	return nil
+}
+
+//////////////////////////////
+// Platform helpers
+//////////////////////////////
+
+// flattenPlatformPackages ... flattens platform package objects to generic interface for state
+func flattenPlatformPackages(packages *[]client.Package) []map[string]interface{} {
+	l := make([]map[string]interface{}, 0)
+
+	for _, item := range *packages {
+		m := make(map[string]interface{})
+		m["id"] = item.PackageID
+		m["name"] = item.PackageName
+
+		l = append(l, m)
+	}
+
+	return l
+}
+
+// flattenPlatformPackageIds ... flattens platform package objects to a map of package name to package ID
+func flattenPlatformPackageIds(packages *[]client.Package) map[string]interface{} {
+	m := make(map[string]interface{})
+
+	for _, item := range *packages {
+		m[item.PackageName] = item.PackageID
+	}
+
+	return m
+}
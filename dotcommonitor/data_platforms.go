@@ -0,0 +1,142 @@
+package dotcommonitor
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/mitchellh/hashstructure"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+func dataPlatforms() *schema.Resource {
+	return &schema.Resource{
+		Read: dataPlatformsRead,
+
+		Schema: map[string]*schema.Schema{
+			"include_unavailable": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"ids_by_name": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"platforms": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"available": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"packages": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"package_ids": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataPlatformsRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var allPlatforms []client.Platform
+	var platforms []client.Platform
+	api := meta.(*client.APIClient)
+
+	includeUnavailable := d.Get("include_unavailable").(bool)
+	err := api.GetPlatforms(&allPlatforms)
+
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get platforms: %s", err)
+	}
+
+	for _, item := range allPlatforms {
+		if item.Available || includeUnavailable {
+			platforms = append(platforms, item)
+		}
+	}
+	log.Printf("[Dotcom-Monitor] %v of %v platforms matched", len(platforms), len(allPlatforms))
+
+	if err1 := populatePlatformsAttributes(d, platforms); err1 != nil {
+		log.Printf("[Dotcom-Monitor] Error setting platforms attributes: %v", err1)
+	}
+
+	return nil
+}
+
+// populatePlatformsAttributes ... fills in necessary schema attributes of the data source
+func populatePlatformsAttributes(d *schema.ResourceData, platforms []client.Platform) error {
+	hash, err := hashstructure.Hash(platforms, nil) // this may not generate a unique ID, but it is fine for data sources
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Error hashing platform data to create ID: %s", err)
+	}
+	d.SetId(fmt.Sprint(hash))
+
+	ids := []int{}
+	names := []string{}
+	idsByName := make(map[string]interface{})
+	l := make([]map[string]interface{}, 0)
+	for _, item := range platforms {
+		ids = append(ids, item.ID)
+		names = append(names, item.Name)
+		idsByName[item.Name] = item.ID
+
+		m := make(map[string]interface{})
+		m["id"] = item.ID
+		m["name"] = item.Name
+		m["available"] = item.Available
+		m["packages"] = flattenPlatformPackages(&item.Packages)
+		m["package_ids"] = flattenPlatformPackageIds(&item.Packages)
+		l = append(l, m)
+	}
+	d.Set("ids", ids)
+	d.Set("names", names)
+	d.Set("ids_by_name", idsByName)
+	d.Set("platforms", l)
+
+	return nil
+}
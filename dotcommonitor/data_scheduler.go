@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
 )
 
@@ -16,12 +17,92 @@ func dataScheduler() *schema.Resource {
 			"id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
-				ExactlyOneOf: []string{"id", "name"},
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
 			},
 			"name": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ExactlyOneOf: []string{"id", "name"},
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
+			"name_prefix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 255),
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
+			"most_recent": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"id", "lowest_id"},
+			},
+			"lowest_id": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"id", "most_recent"},
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"weekly_intervals": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"days": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"from": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"to": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"excluded_time_intervals": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"from": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"to": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"assigned_devices": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"assigned_groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
 			},
 		},
 	}
@@ -35,7 +116,6 @@ func dataSchedulerRead(d *schema.ResourceData, meta interface{}) error {
 	api := meta.(*client.APIClient)
 
 	id := d.Get("id").(int)
-	name := d.Get("name").(string)
 
 	// check which agrument was provided and make the appropriate API call
 	if id != 0 {
@@ -45,14 +125,25 @@ func dataSchedulerRead(d *schema.ResourceData, meta interface{}) error {
 		if err != nil {
 			return fmt.Errorf("[Dotcom-Monitor] Failed to get scheduler: %s", err)
 		}
-		schedulers = append(schedulers, scheduler)
-	} else if name != "" {
-		err := api.GetSchedulersByName(name, &schedulers)
+		if scheduler.ID > 0 {
+			schedulers = append(schedulers, scheduler)
+		}
+	} else {
+		var allSchedulers []client.Scheduler
+		err := api.GetSchedulers(&allSchedulers)
 		if err != nil {
-			return fmt.Errorf("[Dotcom-Monitor] Failed to get schedulers by name: %s", err)
+			return fmt.Errorf("[Dotcom-Monitor] Failed to get schedulers: %s", err)
+		}
+
+		query, match := dataSourceNameQuery(d)
+		for _, item := range allSchedulers {
+			if match(item.Name) {
+				schedulers = append(schedulers, item)
+			}
 		}
 
 		// We cannot process a situation where there is more than one scheduler with the same name
+		//  unless a tie-breaker was requested
 		if len(schedulers) > 1 {
 			// Get the list of ID's
 			ids := make([]int, len(schedulers))
@@ -60,18 +151,22 @@ func dataSchedulerRead(d *schema.ResourceData, meta interface{}) error {
 				ids[i] = item.ID
 			}
 
-			return fmt.Errorf("[Dotcom-Monitor] Query returned %v schedulers from API for name %s - "+
-				"Scheduler ID's returned: %v - "+
-				"Scheduler names must be unique in order to use this data source", len(schedulers), name, ids)
+			index := dataSourceTieBreak(d, ids)
+			if index < 0 {
+				return fmt.Errorf("[Dotcom-Monitor] Query returned %v schedulers from API for %s - "+
+					"Scheduler ID's returned: %v - "+
+					"Scheduler names must be unique, or most_recent or lowest_id must be set, in order to use this data source", len(schedulers), query, ids)
+			}
+			schedulers = []client.Scheduler{schedulers[index]}
 		}
 	}
 
-	// No schedulers found for this name
+	// No schedulers found for this query
 	if len(schedulers) < 1 {
 		return fmt.Errorf("[Dotcom-Monitor] Query did not return any matching schedulers from the API")
 	}
 
-	// If we get this far, we know we only got one scheduler back from the API
+	// If we get this far, we know we only have one scheduler
 	scheduler := schedulers[0]
 	log.Printf("[Dotcom-Monitor] Single scheduler found: %v", &scheduler.Name)
 
@@ -87,7 +182,11 @@ func populateSchedulerAttributes(d *schema.ResourceData, scheduler client.Schedu
 	strID := fmt.Sprint(scheduler.ID)
 	d.SetId(strID)
 	d.Set("name", scheduler.Name)
-	// TODO: Output more attributes?
+	d.Set("description", scheduler.Description)
+	d.Set("weekly_intervals", flattenSchedulerWeeklyIntervalsList(&scheduler.WeeklyIntervals))
+	d.Set("excluded_time_intervals", flattenSchedulerExcludedTimeIntervalsList(&scheduler.ExcludedTimeIntervals))
+	d.Set("assigned_devices", scheduler.AssignedTo.Devices)
+	d.Set("assigned_groups", scheduler.AssignedTo.Groups)
 
 	return nil
 }
@@ -0,0 +1,134 @@
+package dotcommonitor
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/hashstructure"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+func dataSchedulers() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSchedulersRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"device_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"schedulers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"assigned_devices": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+						},
+						"assigned_groups": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeInt},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSchedulersRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var allSchedulers []client.Scheduler
+	api := meta.(*client.APIClient)
+
+	err := api.GetSchedulers(&allSchedulers)
+
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get schedulers: %s", err)
+	}
+
+	// apply the optional filters
+	var schedulers []client.Scheduler
+	for _, item := range allSchedulers {
+		if v, ok := d.GetOk("name_regex"); ok && !regexp.MustCompile(v.(string)).MatchString(item.Name) {
+			continue
+		}
+		if v, ok := d.GetOk("device_id"); ok && !intInList(item.AssignedTo.Devices, v.(int)) {
+			continue
+		}
+		schedulers = append(schedulers, item)
+	}
+	log.Printf("[Dotcom-Monitor] %v of %v schedulers matched", len(schedulers), len(allSchedulers))
+
+	if err1 := populateSchedulersAttributes(d, schedulers); err1 != nil {
+		log.Printf("[Dotcom-Monitor] Error setting schedulers attributes: %v", err1)
+	}
+
+	return nil
+}
+
+// populateSchedulersAttributes ... fills in necessary schema attributes of the data source
+func populateSchedulersAttributes(d *schema.ResourceData, schedulers []client.Scheduler) error {
+	hash, err := hashstructure.Hash(schedulers, nil) // this may not generate a unique ID, but it is fine for data sources
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Error hashing scheduler data to create ID: %s", err)
+	}
+	d.SetId(fmt.Sprint(hash))
+
+	ids := []int{}
+	names := []string{}
+	l := make([]map[string]interface{}, 0)
+	for _, item := range schedulers {
+		ids = append(ids, item.ID)
+		names = append(names, item.Name)
+
+		m := make(map[string]interface{})
+		m["id"] = item.ID
+		m["name"] = item.Name
+		m["description"] = item.Description
+		m["assigned_devices"] = item.AssignedTo.Devices
+		m["assigned_groups"] = item.AssignedTo.Groups
+		l = append(l, m)
+	}
+	d.Set("ids", ids)
+	d.Set("names", names)
+	d.Set("schedulers", l)
+
+	return nil
+}
@@ -3,8 +3,10 @@ package dotcommonitor
 import (
 	"fmt"
 	"log"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
 )
 
@@ -13,13 +15,213 @@ func dataTask() *schema.Resource {
 		Read: dataTaskRead,
 
 		Schema: map[string]*schema.Schema{
+			"id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
+			"name_prefix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringLenBetween(1, 255),
+				ExactlyOneOf: []string{"id", "name", "name_regex", "name_prefix"},
+			},
+			"most_recent": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"id", "lowest_id"},
+			},
+			"lowest_id": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"id", "most_recent"},
 			},
 			"device_id": {
-				Type:         schema.TypeInt,
-				Required:     true,
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+			"request_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"url": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"keyword1": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"keyword2": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"keyword3": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"username": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ssl_check_certificate_authority": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"ssl_check_certificate_cn": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"ssl_check_certificate_date": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"ssl_check_certificate_revocation": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"ssl_check_certificate_usage": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"ssl_expiration_reminder_in_days": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"full_page_download": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"download_html": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"download_frames": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"download_style_sheets": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"download_scripts": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"download_images": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"download_objects": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"download_applets": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"download_additional": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"get_params": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"post_params": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"header_params": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"prepare_script": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"dns_resolve_mode": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"dns_server_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"custom_dns_hosts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"task_type_id": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"timeout": {
+				Type:     schema.TypeInt,
+				Computed: true,
 			},
 		},
 	}
@@ -32,33 +234,66 @@ func dataTaskRead(d *schema.ResourceData, meta interface{}) error {
 	var tasks []client.Task
 	api := meta.(*client.APIClient)
 
+	id := d.Get("id").(int)
 	deviceID := d.Get("device_id").(int)
-	name := d.Get("name").(string)
-	err := api.GetDeviceTasksByName(deviceID, name, &tasks)
 
-	if err != nil {
-		return fmt.Errorf("[Dotcom-Monitor] Failed to get task by name: %s", err)
-	}
+	// check which agrument was provided and make the appropriate API call
+	if id != 0 {
+		var task client.Task
+		task.ID = id
+		err := api.GetTask(&task)
+		if err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to get task: %s", err)
+		}
+		if task.ID > 0 {
+			tasks = append(tasks, task)
+		}
+	} else {
+		// name lookups are scoped to a single device
+		if deviceID == 0 {
+			return fmt.Errorf("[Dotcom-Monitor] device_id must be set when looking up a task by name")
+		}
 
-	// No tasks found for the given name on the device
-	if len(tasks) < 1 {
-		return fmt.Errorf("[Dotcom-Monitor] Query did not return any tasks from API")
-	}
+		var allTasks []client.Task
+		device := &client.Device{}
+		device.ID = deviceID
+		err := api.GetTaskListByDevice(device, &allTasks)
+		if err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to get tasks by device: %s", err)
+		}
 
-	// We cannot process a situation where there is more than one task with the same name
-	if len(tasks) > 1 {
-		// Get the list of ID's
-		ids := make([]int, len(tasks))
-		for i, item := range tasks {
-			ids[i] = item.ID
+		query, match := dataSourceNameQuery(d)
+		for _, item := range allTasks {
+			if match(item.Name) {
+				tasks = append(tasks, item)
+			}
 		}
 
-		return fmt.Errorf("[Dotcom-Monitor] Query returned %v tasks from API for name %s on device ID %v - "+
-			"Task ID's returned: %v - "+
-			"Tasks must be updated to be unique in order to use this data source", len(tasks), name, deviceID, ids)
+		// We cannot process a situation where there is more than one task with the same name
+		//  unless a tie-breaker was requested
+		if len(tasks) > 1 {
+			// Get the list of ID's
+			ids := make([]int, len(tasks))
+			for i, item := range tasks {
+				ids[i] = item.ID
+			}
+
+			index := dataSourceTieBreak(d, ids)
+			if index < 0 {
+				return fmt.Errorf("[Dotcom-Monitor] Query returned %v tasks from API for %s on device ID %v - "+
+					"Task ID's returned: %v - "+
+					"Tasks must be updated to be unique, or most_recent or lowest_id must be set, in order to use this data source", len(tasks), query, deviceID, ids)
+			}
+			tasks = []client.Task{tasks[index]}
+		}
 	}
 
-	// If we get this far, we know we only got one device back from the API
+	// No tasks found for the given query on the device
+	if len(tasks) < 1 {
+		return fmt.Errorf("[Dotcom-Monitor] Query did not return any tasks from API")
+	}
+
+	// If we get this far, we know we only have one task
 	task := tasks[0]
 	log.Printf("[Dotcom-Monitor] Single task found: %v", &task.Name)
 
@@ -74,6 +309,40 @@ func populateTaskAttributes(d *schema.ResourceData, task client.Task) error {
 	// Set ID
 	strID := fmt.Sprint(task.ID)
 	d.SetId(strID)
+	d.Set("name", task.Name)
+	d.Set("device_id", task.DeviceID)
+	d.Set("request_type", task.RequestType)
+	d.Set("url", task.URL)
+	d.Set("keyword1", task.Keyword1)
+	d.Set("keyword2", task.Keyword2)
+	d.Set("keyword3", task.Keyword3)
+	d.Set("username", task.UserName)
+	d.Set("ssl_check_certificate_authority", task.SSLCheckCertificateAuthority)
+	d.Set("ssl_check_certificate_cn", task.SSLCheckCertificateCN)
+	d.Set("ssl_check_certificate_date", task.SSLCheckCertificateDate)
+	d.Set("ssl_check_certificate_revocation", task.SSLCheckCertificateRevocation)
+	d.Set("ssl_check_certificate_usage", task.SSLCheckCertificateUsage)
+	if days, err := strconv.Atoi(task.SSLExpirationReminderInDays); err == nil { // HACK: stored as string in API
+		d.Set("ssl_expiration_reminder_in_days", days)
+	}
+	d.Set("full_page_download", task.FullPageDownload)
+	d.Set("download_html", task.DownloadHTML)
+	d.Set("download_frames", task.DownloadFrames)
+	d.Set("download_style_sheets", task.DownloadStyleSheets)
+	d.Set("download_scripts", task.DownloadScripts)
+	d.Set("download_images", task.DownloadImages)
+	d.Set("download_objects", task.DownloadObjects)
+	d.Set("download_applets", task.DownloadApplets)
+	d.Set("download_additional", task.DownloadAdditional)
+	d.Set("get_params", flattenTaskParamList(&task.GetParams))
+	d.Set("post_params", flattenTaskParamList(&task.PostParams))
+	d.Set("header_params", flattenTaskParamList(&task.HeaderParams))
+	d.Set("prepare_script", task.PrepareScript)
+	d.Set("dns_resolve_mode", task.DNSResolveMode)
+	d.Set("dns_server_ip", task.DNSserverIP)
+	d.Set("custom_dns_hosts", flattenCustomDnsHostsString(task.CustomDNSHosts))
+	d.Set("task_type_id", task.TaskTypeID)
+	d.Set("timeout", task.Timeout/1000) // API stores timeout in milliseconds, see resourceTaskRead
 
 	return nil
 }
@@ -0,0 +1,153 @@
+package dotcommonitor
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/mitchellh/hashstructure"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+func dataTasks() *schema.Resource {
+	return &schema.Resource{
+		Read: dataTasksRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"device_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"platform_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,                                         // ServerView
+				ValidateFunc: validation.IntInSlice([]int{1, 3, 7, 12}), // 1=ServerView, 3=MetricsView, 7=BrowserView, 12=WebView
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"tasks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"device_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"request_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"url": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"task_type_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"timeout": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataTasksRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	var allTasks []client.Task
+	api := meta.(*client.APIClient)
+
+	// when a device is given only its tasks are fetched, otherwise every device on the platform is walked
+	if v, ok := d.GetOk("device_id"); ok {
+		device := &client.Device{}
+		device.ID = v.(int)
+		if err := api.GetTaskListByDevice(device, &allTasks); err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to get tasks by device: %s", err)
+		}
+	} else {
+		platformID := d.Get("platform_id").(int)
+		if err := api.GetTasks(platformID, &allTasks); err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to get tasks: %s", err)
+		}
+	}
+
+	// apply the optional filters
+	var tasks []client.Task
+	for _, item := range allTasks {
+		if v, ok := d.GetOk("name_regex"); ok && !regexp.MustCompile(v.(string)).MatchString(item.Name) {
+			continue
+		}
+		tasks = append(tasks, item)
+	}
+	log.Printf("[Dotcom-Monitor] %v of %v tasks matched", len(tasks), len(allTasks))
+
+	if err1 := populateTasksAttributes(d, tasks); err1 != nil {
+		log.Printf("[Dotcom-Monitor] Error setting tasks attributes: %v", err1)
+	}
+
+	return nil
+}
+
+// populateTasksAttributes ... fills in necessary schema attributes of the data source
+func populateTasksAttributes(d *schema.ResourceData, tasks []client.Task) error {
+	hash, err := hashstructure.Hash(tasks, nil) // this may not generate a unique ID, but it is fine for data sources
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Error hashing task data to create ID: %s", err)
+	}
+	d.SetId(fmt.Sprint(hash))
+
+	ids := []int{}
+	names := []string{}
+	l := make([]map[string]interface{}, 0)
+	for _, item := range tasks {
+		ids = append(ids, item.ID)
+		names = append(names, item.Name)
+
+		m := make(map[string]interface{})
+		m["id"] = item.ID
+		m["name"] = item.Name
+		m["device_id"] = item.DeviceID
+		m["request_type"] = item.RequestType
+		m["url"] = item.URL
+		m["task_type_id"] = item.TaskTypeID
+		m["timeout"] = item.Timeout / 1000 // API stores timeout in milliseconds, see resourceTaskRead
+		l = append(l, m)
+	}
+	d.Set("ids", ids)
+	d.Set("names", names)
+	d.Set("tasks", l)
+
+	return nil
+}
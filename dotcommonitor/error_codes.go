@@ -0,0 +1,191 @@
+package dotcommonitor
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+// ignoreErrorsTypes ... error types accepted by the API for filter ignored errors
+var ignoreErrorsTypes = []string{"Validation", "Runtime", "CustomScript", "Certificate", "Cryptographic", "Tcp", "Dns", "Udp", "Http", "Ftp", "Sftp", "Smtp", "Pop3", "Imap", "Icmp", "IcmpV6", "DnsBL", "Media", "Sip"}
+
+// errorCode ... a known error code of an error type
+type errorCode struct {
+	Code        int
+	Name        string
+	Description string
+}
+
+// errorCodeCatalog ... the known error codes of an error type
+// Min-Max is the standard range of the type's codes, a Max of 0 means the type has no standard range
+type errorCodeCatalog struct {
+	Min   int
+	Max   int
+	Codes []errorCode
+}
+
+// errorCodeCatalogs ... known error codes, keyed by lower case error type
+// Dotcom-Monitor does not publish its error codes, so only the types that report standard protocol codes have a catalog.
+// Http codes come from the IANA HTTP Status Code Registry (https://www.iana.org/assignments/http-status-codes),
+// Dns codes from the IANA DNS RCODEs registry (https://www.iana.org/assignments/dns-parameters/dns-parameters.xhtml#dns-parameters-6)
+// Types without an entry accept any code without a warning
+var errorCodeCatalogs = map[string]errorCodeCatalog{
+	"http": {
+		Min: 100,
+		Max: 599,
+		Codes: []errorCode{
+			{100, "continue", "Continue"},
+			{101, "switching_protocols", "Switching Protocols"},
+			{200, "ok", "OK"},
+			{201, "created", "Created"},
+			{202, "accepted", "Accepted"},
+			{203, "non_authoritative_information", "Non-Authoritative Information"},
+			{204, "no_content", "No Content"},
+			{205, "reset_content", "Reset Content"},
+			{206, "partial_content", "Partial Content"},
+			{300, "multiple_choices", "Multiple Choices"},
+			{301, "moved_permanently", "Moved Permanently"},
+			{302, "found", "Found"},
+			{303, "see_other", "See Other"},
+			{304, "not_modified", "Not Modified"},
+			{305, "use_proxy", "Use Proxy"},
+			{307, "temporary_redirect", "Temporary Redirect"},
+			{308, "permanent_redirect", "Permanent Redirect"},
+			{400, "bad_request", "Bad Request"},
+			{401, "unauthorized", "Unauthorized"},
+			{402, "payment_required", "Payment Required"},
+			{403, "forbidden", "Forbidden"},
+			{404, "not_found", "Not Found"},
+			{405, "method_not_allowed", "Method Not Allowed"},
+			{406, "not_acceptable", "Not Acceptable"},
+			{407, "proxy_authentication_required", "Proxy Authentication Required"},
+			{408, "request_timeout", "Request Timeout"},
+			{409, "conflict", "Conflict"},
+			{410, "gone", "Gone"},
+			{411, "length_required", "Length Required"},
+			{412, "precondition_failed", "Precondition Failed"},
+			{413, "payload_too_large", "Payload Too Large"},
+			{414, "uri_too_long", "URI Too Long"},
+			{415, "unsupported_media_type", "Unsupported Media Type"},
+			{416, "range_not_satisfiable", "Range Not Satisfiable"},
+			{417, "expectation_failed", "Expectation Failed"},
+			{421, "misdirected_request", "Misdirected Request"},
+			{422, "unprocessable_entity", "Unprocessable Entity"},
+			{423, "locked", "Locked"},
+			{424, "failed_dependency", "Failed Dependency"},
+			{425, "too_early", "Too Early"},
+			{426, "upgrade_required", "Upgrade Required"},
+			{428, "precondition_required", "Precondition Required"},
+			{429, "too_many_requests", "Too Many Requests"},
+			{431, "request_header_fields_too_large", "Request Header Fields Too Large"},
+			{451, "unavailable_for_legal_reasons", "Unavailable For Legal Reasons"},
+			{500, "internal_server_error", "Internal Server Error"},
+			{501, "not_implemented", "Not Implemented"},
+			{502, "bad_gateway", "Bad Gateway"},
+			{503, "service_unavailable", "Service Unavailable"},
+			{504, "gateway_timeout", "Gateway Timeout"},
+			{505, "http_version_not_supported", "HTTP Version Not Supported"},
+			{506, "variant_also_negotiates", "Variant Also Negotiates"},
+			{507, "insufficient_storage", "Insufficient Storage"},
+			{508, "loop_detected", "Loop Detected"},
+			{510, "not_extended", "Not Extended"},
+			{511, "network_authentication_required", "Network Authentication Required"},
+		},
+	},
+	"dns": {
+		Min: 0,
+		Max: 4095,
+		Codes: []errorCode{
+			{0, "no_error", "No error"},
+			{1, "form_err", "Format error"},
+			{2, "serv_fail", "Server failure"},
+			{3, "nx_domain", "Non-existent domain"},
+			{4, "not_imp", "Not implemented"},
+			{5, "refused", "Query refused"},
+			{6, "yx_domain", "Name exists when it should not"},
+			{7, "yx_rrset", "RR set exists when it should not"},
+			{8, "nx_rrset", "RR set that should exist does not"},
+			{9, "not_auth", "Server not authoritative for zone"},
+			{10, "not_zone", "Name not contained in zone"},
+			{11, "dso_type_ni", "DSO-TYPE not implemented"},
+			{16, "bad_vers", "Bad OPT version or TSIG signature failure"},
+			{17, "bad_key", "Key not recognized"},
+			{18, "bad_time", "Signature out of time window"},
+			{19, "bad_mode", "Bad TKEY mode"},
+			{20, "bad_name", "Duplicate key name"},
+			{21, "bad_alg", "Algorithm not supported"},
+			{22, "bad_trunc", "Bad truncation"},
+			{23, "bad_cookie", "Bad or missing server cookie"},
+		},
+	},
+}
+
+// lookupErrorCodeCatalog ... returns the catalog of an error type, matched case-insensitively
+func lookupErrorCodeCatalog(errorType string) (errorCodeCatalog, bool) {
+	catalog, ok := errorCodeCatalogs[strings.ToLower(errorType)]
+	return catalog, ok
+}
+
+// lookup ... returns the known error code with the given number
+func (c errorCodeCatalog) lookup(code int) (errorCode, bool) {
+	for _, item := range c.Codes {
+		if item.Code == code {
+			return item, true
+		}
+	}
+	return errorCode{}, false
+}
+
+// inBounds ... returns whether the code is in the standard range of the catalog's error type
+func (c errorCodeCatalog) inBounds(code int) bool {
+	return c.Max == 0 || (code >= c.Min && code <= c.Max)
+}
+
+// checkIgnoreErrorsCodes ... checks ignored error codes & ranges against the catalog of their error type
+// It only returns warnings, as the API does not document which codes each type can report
+func checkIgnoreErrorsCodes(errorType string, codes []int, ranges []client.ErrorCodeToIgnoreRange) []string {
+	catalog, ok := lookupErrorCodeCatalog(errorType)
+	if !ok {
+		return nil
+	}
+
+	var warnings []string
+	for _, code := range codes {
+		if !catalog.inBounds(code) {
+			warnings = append(warnings, fmt.Sprintf("ignore_errors of type %q has code %v, outside the standard %s range of %v to %v", errorType, code, errorType, catalog.Min, catalog.Max))
+		} else if _, known := catalog.lookup(code); !known {
+			warnings = append(warnings, fmt.Sprintf("ignore_errors of type %q has code %v, which is not a known %s error code", errorType, code, errorType))
+		}
+	}
+
+	for _, r := range ranges {
+		if !catalog.inBounds(r.From) || !catalog.inBounds(r.To) {
+			warnings = append(warnings, fmt.Sprintf("ignore_errors of type %q has range %v-%v, outside the standard %s range of %v to %v", errorType, r.From, r.To, errorType, catalog.Min, catalog.Max))
+			continue
+		}
+		known := false
+		for _, item := range catalog.Codes {
+			if item.Code >= r.From && item.Code <= r.To {
+				known = true
+				break
+			}
+		}
+		if !known {
+			warnings = append(warnings, fmt.Sprintf("ignore_errors of type %q has range %v-%v, which does not contain any known %s error code", errorType, r.From, r.To, errorType))
+		}
+	}
+
+	return warnings
+}
+
+// errorCodeCatalogTypes ... returns the error types that have a catalog, sorted
+func errorCodeCatalogTypes() []string {
+	types := make([]string, 0, len(errorCodeCatalogs))
+	for errorType := range errorCodeCatalogs {
+		types = append(types, errorType)
+	}
+	sort.Strings(types)
+	return types
+}
@@ -0,0 +1,140 @@
+package dotcommonitor
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+// fakeAPI ... an in-memory Dotcom-Monitor API storing objects by endpoint, e.g. "scheduler/1"
+//
+// Updates behave like the API's: a field left out of the request keeps its stored
+// value, so a list can only be cleared by sending it empty.
+type fakeAPI struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	objects map[string]map[string]interface{}
+	updates map[string][]string // endpoint to the bodies of the updates received
+}
+
+// newFakeAPI ... starts a fake API holding the objects, stopped when the test ends
+func newFakeAPI(tb testing.TB, objects map[string]interface{}) *fakeAPI {
+	f := &fakeAPI{
+		objects: make(map[string]map[string]interface{}),
+		updates: make(map[string][]string),
+	}
+	for endpoint, object := range objects {
+		js, _ := json.Marshal(object)
+		var fields map[string]interface{}
+		json.Unmarshal(js, &fields)
+		f.objects[endpoint] = fields
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	tb.Cleanup(f.Close)
+	return f
+}
+
+// client ... returns a logged in client sending its requests to the fake API
+func (f *fakeAPI) client(tb testing.TB) *client.APIClient {
+	target, _ := url.Parse(f.URL)
+	api := client.NewAPIClient()
+	api.Transport = rewriteTransport{target}
+	if err := api.Login("test-uid"); err != nil {
+		tb.Fatalf("login failed: %s", err)
+	}
+	return api
+}
+
+// get ... decodes the stored object into v
+func (f *fakeAPI) get(tb testing.TB, endpoint string, v interface{}) {
+	f.mu.Lock()
+	js, _ := json.Marshal(f.objects[endpoint])
+	f.mu.Unlock()
+	if err := json.Unmarshal(js, v); err != nil {
+		tb.Fatalf("unable to decode %s: %s", endpoint, err)
+	}
+}
+
+// lastUpdate ... returns the body of the last update received for the endpoint
+func (f *fakeAPI) lastUpdate(endpoint string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.updates[endpoint]) == 0 {
+		return ""
+	}
+	return f.updates[endpoint][len(f.updates[endpoint])-1]
+}
+
+func (f *fakeAPI) serve(w http.ResponseWriter, r *http.Request) {
+	endpoint := strings.TrimPrefix(r.URL.Path, "/config_api_v1/")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body interface{}
+	switch {
+	case r.Method == "POST" && endpoint == "login":
+		http.SetCookie(w, &http.Cookie{Name: client.AuthCookieName, Value: "session"})
+		body = client.ResponseBlock{Success: true}
+	case r.Method == "POST":
+		object, ok := f.objects[endpoint]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		js, _ := ioutil.ReadAll(r.Body)
+		var fields map[string]interface{}
+		if err := json.Unmarshal(js, &fields); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.updates[endpoint] = append(f.updates[endpoint], string(js))
+		mergeFakeObject(object, fields)
+		body = client.ResponseBlock{Success: true}
+	case r.Method == "GET":
+		object, ok := f.objects[endpoint]
+		if !ok {
+			object = map[string]interface{}{}
+		}
+		body = object
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(body)
+}
+
+// mergeFakeObject ... applies the fields sent in an update, keeping the fields left out
+func mergeFakeObject(object map[string]interface{}, fields map[string]interface{}) {
+	for key, value := range fields {
+		nested, isObject := value.(map[string]interface{})
+		stored, wasObject := object[key].(map[string]interface{})
+		if isObject && wasObject {
+			mergeFakeObject(stored, nested)
+			continue
+		}
+		object[key] = value
+	}
+}
+
+// rewriteTransport ... sends requests for the API base URL to the fake API instead
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r := req.Clone(req.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	r.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
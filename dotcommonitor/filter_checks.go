@@ -0,0 +1,47 @@
+package dotcommonitor
+
+import (
+	"fmt"
+
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+// unknownTaskCount ... task count passed to checkFilterRules when the tasks of the device are not known yet
+const unknownTaskCount = -1
+
+// checkFilterRules ... returns the reasons why the filter rule can never trigger on a device with the given number of locations & tasks
+func checkFilterRules(rule client.Rule, locationCount int, taskCount int) []string {
+	var reasons []string
+
+	if rule.NumberOfLocations > locationCount {
+		reasons = append(reasons, fmt.Sprintf("rules.num_locations is %v, but the device only monitors from %v locations", rule.NumberOfLocations, locationCount))
+	}
+	if taskCount != unknownTaskCount && rule.NumberOfTasks > taskCount {
+		reasons = append(reasons, fmt.Sprintf("rules.num_tasks is %v, but the device only has %v tasks", rule.NumberOfTasks, taskCount))
+	}
+
+	return reasons
+}
+
+// checkFilterOnDevice ... reads the filter & the tasks of an existing device, and returns the reasons why the filter can never trigger on it
+// A deviceID of 0 is a device that does not exist yet, its tasks are not checked
+func checkFilterOnDevice(api *client.APIClient, filterID int, deviceID int, locationCount int) ([]string, error) {
+	filter := &client.Filter{ID: filterID}
+	if err := api.GetFilter(filter); err != nil {
+		return nil, fmt.Errorf("[Dotcom-Monitor] Failed to get filter: %s", err)
+	}
+	if !(filter.ID > 0) {
+		return nil, nil // a filter that does not exist yet is checked when the device is planned again
+	}
+
+	taskCount := unknownTaskCount
+	if deviceID > 0 {
+		var taskIDs []int
+		if err := api.GetTaskIdsByDevice(&client.Device{ID: deviceID}, &taskIDs); err != nil {
+			return nil, fmt.Errorf("[Dotcom-Monitor] Failed to get device tasks: %s", err)
+		}
+		taskCount = len(taskIDs)
+	}
+
+	return checkFilterRules(filter.Rules, locationCount, taskCount), nil
+}
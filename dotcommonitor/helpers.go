@@ -1,6 +1,9 @@
 package dotcommonitor
 
 import (
+	"fmt"
+	"log"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -71,3 +74,120 @@ func stringInList(stringList []string, s string) bool {
 	result := (index < len(stringList)) && (stringList[index] == s)
 	return result
 }
+
+// intListDifference .. returns the ints of the first list that are not in the second list
+func intListDifference(intList []int, other []int) []int {
+	exclude := intSetOf(other)
+	result := []int{}
+	for _, item := range intList {
+		if !exclude[item] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// intListIntersection .. returns the ints of the first list that are also in the second list
+func intListIntersection(intList []int, other []int) []int {
+	include := intSetOf(other)
+	result := []int{}
+	for _, item := range intList {
+		if include[item] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// intListUnion .. returns the ints that are in either list, without duplicates
+func intListUnion(intList []int, other []int) []int {
+	seen := make(map[int]bool, len(intList)+len(other))
+	result := []int{}
+	for _, list := range [][]int{intList, other} {
+		for _, item := range list {
+			if !seen[item] {
+				seen[item] = true
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+// intSetOf .. returns a lookup of the ints in the list, leaving the list untouched
+func intSetOf(intList []int) map[int]bool {
+	result := make(map[int]bool, len(intList))
+	for _, item := range intList {
+		result[item] = true
+	}
+	return result
+}
+
+//////////////////////////////
+// Data source helpers
+//////////////////////////////
+
+// dataSourceNameQuery ... builds a matcher from whichever of the name, name_regex & name_prefix arguments was provided
+// Also returns a description of the query for use in diagnostics
+func dataSourceNameQuery(d *schema.ResourceData) (string, func(string) bool) {
+	if v, ok := d.GetOk("name_regex"); ok {
+		re := regexp.MustCompile(v.(string)) // already checked by validation.StringIsValidRegExp
+		return fmt.Sprintf("name_regex %q", v), re.MatchString
+	}
+	if v, ok := d.GetOk("name_prefix"); ok {
+		prefix := v.(string)
+		return fmt.Sprintf("name_prefix %q", prefix), func(s string) bool { return strings.HasPrefix(s, prefix) }
+	}
+	name := d.Get("name").(string)
+	return fmt.Sprintf("name %s", name), func(s string) bool { return s == name }
+}
+
+// dataSourceTieBreak ... picks the index of the ID to use when a query matched more than one object
+// IDs are handed out sequentially by the API, so the highest ID is the most recently created object
+// Returns -1 if neither most_recent nor lowest_id was set
+func dataSourceTieBreak(d *schema.ResourceData, ids []int) int {
+	mostRecent := d.Get("most_recent").(bool)
+	lowestID := d.Get("lowest_id").(bool)
+	if !mostRecent && !lowestID {
+		return -1
+	}
+
+	index := 0
+	for i, id := range ids {
+		if (mostRecent && id > ids[index]) || (lowestID && id < ids[index]) {
+			index = i
+		}
+	}
+
+	return index
+}
+
+//////////////////////////////
+// Plan helpers
+//////////////////////////////
+
+// checkPlanWarnings ... fails the plan with the warnings about the subject, or only logs them when ignore_plan_warnings is set
+// Terraform does not show warnings returned at plan time by CustomizeDiff, so they are errors by default
+func checkPlanWarnings(d *schema.ResourceDiff, subject string, warnings []string) error {
+	if len(warnings) == 0 {
+		return nil
+	}
+
+	if d.Get("ignore_plan_warnings").(bool) {
+		for _, item := range warnings {
+			log.Printf("[WARN] [Dotcom-Monitor] %s: %s", subject, item)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("[Dotcom-Monitor] %s: %s (set ignore_plan_warnings to true to accept this)", subject, strings.Join(warnings, "; "))
+}
+
+// ignorePlanWarningsSchema ... the opt-out of checkPlanWarnings
+func ignorePlanWarningsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+	}
+}
@@ -0,0 +1,25 @@
+package dotcommonitor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIntListHelpersKeepInputs(t *testing.T) {
+	list := []int{5, 3, 9, 1}
+	other := []int{9, 2, 5}
+
+	if got, want := intListDifference(list, other), []int{3, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("intListDifference = %v, want %v", got, want)
+	}
+	if got, want := intListIntersection(list, other), []int{5, 9}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("intListIntersection = %v, want %v", got, want)
+	}
+	if got, want := intListUnion(list, other), []int{5, 3, 9, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("intListUnion = %v, want %v", got, want)
+	}
+
+	if !reflect.DeepEqual(list, []int{5, 3, 9, 1}) || !reflect.DeepEqual(other, []int{9, 2, 5}) {
+		t.Fatalf("inputs were modified: %v, %v", list, other)
+	}
+}
@@ -0,0 +1,46 @@
+package dotcommonitor
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+// checkDeviceLocations ... compares the location ID's of a device with the locations of its platform
+// Deleted & unknown locations are problems the API rejects, unavailable & restrictive locations only warnings
+func checkDeviceLocations(locations []client.Location, ids []int) (problems []string, warnings []string) {
+	byID := make(map[int]client.Location)
+	for _, item := range locations {
+		byID[item.ID] = item
+	}
+
+	sort.Ints(ids)
+	for _, id := range ids {
+		location, ok := byID[id]
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("location ID %v does not exist on the platform", id))
+		case location.IsDeleted:
+			problems = append(problems, fmt.Sprintf("location ID %v (%s) has been deleted", id, location.Name))
+		case !location.Available:
+			warnings = append(warnings, fmt.Sprintf("location ID %v (%s) is not available", id, location.Name))
+		}
+		if ok && isRestrictiveLocation(location, nil) {
+			warnings = append(warnings, fmt.Sprintf("location ID %v (%s) may be restricted by country-wide firewalls or regulations", id, location.Name))
+		}
+	}
+
+	return problems, warnings
+}
+
+// checkLocationsOnPlatform ... reads the locations of the platform, and returns the problems & warnings of the location ID's of a device
+func checkLocationsOnPlatform(api *client.APIClient, platformID int, ids []int) ([]string, []string, error) {
+	var locations []client.Location
+	if err := api.GetLocationsWithDeleted(platformID, &locations); err != nil {
+		return nil, nil, fmt.Errorf("[Dotcom-Monitor] Failed to get locations: %s", err)
+	}
+
+	problems, warnings := checkDeviceLocations(locations, ids)
+	return problems, warnings, nil
+}
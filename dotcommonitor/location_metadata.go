@@ -0,0 +1,135 @@
+package dotcommonitor
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+// locationRegions ... regions a location can belong to: Africa, Asia (incl. the Middle East), Europe, North America, Oceania, South America
+var locationRegions = []string{"AF", "AS", "EU", "NA", "OC", "SA"}
+
+// locationInfo ... metadata the API does not return for a location
+// An ID of 0 matches the location by name only
+type locationInfo struct {
+	ID          int
+	Name        string
+	Region      string
+	Country     string // ISO 3166-1 alpha-2
+	Restrictive bool   // subject to country-wide firewalls, government regulations, restrictions, etc.
+}
+
+// locationMetadata ... metadata of the public locations, embedded in the provider
+// This list matches the public location list of the device docs (last updated: July 2021), and can be updated as appropriate.
+// Entries can be overridden per data source with location_metadata
+var locationMetadata = []locationInfo{
+	// North America
+	{1, "Minneapolis", "NA", "US", false},
+	{2, "New York", "NA", "US", false},
+	{4, "San Francisco", "NA", "US", false},
+	{6, "Miami", "NA", "US", false},
+	{13, "Montreal", "NA", "CA", false},
+	{15, "Denver", "NA", "US", false},
+	{18, "Dallas", "NA", "US", false},
+	{43, "Washington DC", "NA", "US", false},
+	{68, "N. Virginia", "NA", "US", false},
+	{125, "IPv6 San Franciso", "NA", "US", false},
+	{138, "Seattle", "NA", "US", false},
+	// South America
+	{73, "Buenos Aires", "SA", "AR", false},
+	// Europe
+	{3, "London", "EU", "GB", false},
+	{14, "Frankfurt", "EU", "DE", false},
+	{19, "Amsterdam", "EU", "NL", false},
+	{97, "Paris", "EU", "FR", false},
+	{113, "Warsaw", "EU", "PL", false},
+	{153, "Copenhagen", "EU", "DK", false},
+	{233, "Madrid", "EU", "ES", false},
+	// Asia
+	{11, "Hong Kong", "AS", "HK", true},
+	{23, "Tel-Aviv", "AS", "IL", false},
+	{71, "Tokyo", "AS", "JP", false},
+	{72, "Shanghai", "AS", "CN", true},
+	{118, "Mumbai", "AS", "IN", false},
+	{184, "Beijing", "AS", "CN", true},
+	{445, "Chengdu", "AS", "CN", true},
+	{446, "Guangzhou", "AS", "CN", true},
+	{447, "Qingdao", "AS", "CN", true},
+	{448, "Shenzhen", "AS", "CN", true},
+	// Oceania
+	{17, "Brisbane", "OC", "AU", false},
+	{181, "Sydney", "OC", "AU", false},
+	// Africa
+	{74, "Johannesburg", "AF", "ZA", false},
+}
+
+// lookupLocationInfo ... finds the metadata of a location, overrides first, then the embedded table
+func lookupLocationInfo(location client.Location, overrides []locationInfo) (locationInfo, bool) {
+	for _, table := range [][]locationInfo{overrides, locationMetadata} {
+		if info, ok := findLocationInfo(table, location); ok {
+			return info, true
+		}
+	}
+	return locationInfo{}, false
+}
+
+// findLocationInfo ... finds the metadata of a location in a table, an ID match wins over a name match
+func findLocationInfo(table []locationInfo, location client.Location) (locationInfo, bool) {
+	for _, item := range table {
+		if item.ID > 0 && item.ID == location.ID {
+			return item, true
+		}
+	}
+	for _, item := range table {
+		if item.ID == 0 && strings.EqualFold(item.Name, location.Name) {
+			return item, true
+		}
+	}
+	return locationInfo{}, false
+}
+
+// isRestrictiveLocation ... checks if the location is considered restrictive by its metadata
+func isRestrictiveLocation(location client.Location, overrides []locationInfo) bool {
+	info, ok := lookupLocationInfo(location, overrides)
+	return ok && info.Restrictive
+}
+
+// spreadLocations ... picks count locations round-robin across regions, so that a small count still covers every region
+// Regions are visited in alphabetical order with locations without a known region last, and locations within a region by ID,
+//  so the same input always gives the same selection
+func spreadLocations(locations []client.Location, overrides []locationInfo, count int) []client.Location {
+	byRegion := make(map[string][]client.Location)
+	for _, item := range locations {
+		info, _ := lookupLocationInfo(item, overrides)
+		byRegion[info.Region] = append(byRegion[info.Region], item)
+	}
+
+	regions := make([]string, 0, len(byRegion))
+	for region, items := range byRegion {
+		if region != "" {
+			regions = append(regions, region)
+		}
+		sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	}
+	sort.Strings(regions)
+	if _, ok := byRegion[""]; ok {
+		regions = append(regions, "")
+	}
+
+	selected := []client.Location{}
+	for i := 0; len(selected) < count; i++ {
+		picked := false
+		for _, region := range regions {
+			if i < len(byRegion[region]) && len(selected) < count {
+				selected = append(selected, byRegion[region][i])
+				picked = true
+			}
+		}
+		if !picked {
+			break
+		}
+	}
+
+	return selected
+}
@@ -4,6 +4,8 @@ import (
 	"sync"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
 )
 
 var mutex = &sync.Mutex{}
@@ -18,24 +20,57 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("DOTCOM_MONITOR_UID", nil),
 				Description: "Customer UID token",
 			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      client.DefaultFanOutWorkers,
+				ValidateFunc: validation.IntBetween(1, 32),
+				Description:  "Number of concurrent API requests used when looking up lists of objects",
+			},
+			"requests_per_second": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of API requests per second, 0 means unlimited",
+			},
+			"disable_response_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Disable caching of platform, location and ID list responses for the duration of a run",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"dotcommonitor_task":      resourceTask(),
-			"dotcommonitor_device":    resourceDevice(),
-			"dotcommonitor_group":     resourceGroup(),
-			"dotcommonitor_scheduler": resourceScheduler(),
-			"dotcommonitor_filter":    resourceFilter(),
+			"dotcommonitor_task":                 resourceTask(),
+			"dotcommonitor_device":               resourceDevice(),
+			"dotcommonitor_group":                resourceGroup(),
+			"dotcommonitor_group_address":        resourceGroupAddress(),
+			"dotcommonitor_scheduler":            resourceScheduler(),
+			"dotcommonitor_filter":               resourceFilter(),
+			"dotcommonitor_filter_assignment":    resourceFilterAssignment(),
+			"dotcommonitor_maintenance_window":   resourceMaintenanceWindow(),
+			"dotcommonitor_scheduler_assignment": resourceSchedulerAssignment(),
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"dotcommonitor_task":      dataTask(),
-			"dotcommonitor_device":    dataDevice(),
-			"dotcommonitor_group":     dataGroup(),
-			"dotcommonitor_location":  dataLocation(),
-			"dotcommonitor_locations": dataLocations(),
-			"dotcommonitor_scheduler": dataScheduler(),
-			"dotcommonitor_filter":    dataFilter(),
+			"dotcommonitor_task":          dataTask(),
+			"dotcommonitor_tasks":         dataTasks(),
+			"dotcommonitor_device":        dataDevice(),
+			"dotcommonitor_devices":       dataDevices(),
+			"dotcommonitor_group":         dataGroup(),
+			"dotcommonitor_groups":        dataGroups(),
+			"dotcommonitor_location":      dataLocation(),
+			"dotcommonitor_locations":     dataLocations(),
+			"dotcommonitor_platform":      dataPlatform(),
+			"dotcommonitor_platforms":     dataPlatforms(),
+			"dotcommonitor_scheduler":     dataScheduler(),
+			"dotcommonitor_schedulers":    dataSchedulers(),
+			"dotcommonitor_filter":        dataFilter(),
+			"dotcommonitor_filters":       dataFilters(),
+			"dotcommonitor_error_codes":   dataErrorCodes(),
+			"dotcommonitor_filter_checks": dataFilterChecks(),
 		},
 
 		ConfigureFunc: providerConfigure,
@@ -44,7 +79,10 @@ func Provider() *schema.Provider {
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	config := Config{
-		UID: d.Get("uid").(string),
+		UID:                   d.Get("uid").(string),
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
+		RequestsPerSecond:     d.Get("requests_per_second").(int),
+		DisableResponseCache:  d.Get("disable_response_cache").(bool),
 	}
 
 	return config.Client()
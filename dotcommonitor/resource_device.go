@@ -1,9 +1,11 @@
 package dotcommonitor
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -19,6 +21,7 @@ func resourceDevice() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceDeviceCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -30,12 +33,24 @@ func resourceDevice() *schema.Resource {
 				Required: true,
 				Elem:     &schema.Schema{Type: schema.TypeInt},
 			},
+			"min_locations": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 			"platform_id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
 				Default:      1,
 				ValidateFunc: validation.IntInSlice([]int{1, 3, 7}), // 1=ServerView, 3=MetricsView, 7=BrowserView
 			},
+			"package_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
 			"frequency": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -71,10 +86,12 @@ func resourceDevice() *schema.Resource {
 			"filter_id": {
 				Type:     schema.TypeInt,
 				Optional: true,
+				Computed: true,
 			},
 			"scheduler_id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.IntAtLeast(0),
 			},
 			"notifications_groups": {
@@ -95,6 +112,7 @@ func resourceDevice() *schema.Resource {
 					},
 				},
 			},
+			"ignore_plan_warnings": ignorePlanWarningsSchema(),
 		},
 	}
 }
@@ -111,6 +129,7 @@ func resourceDeviceCreate(d *schema.ResourceData, meta interface{}) error {
 	device := &client.Device{
 		Name:                    d.Get("name").(string),
 		PlatformID:              d.Get("platform_id").(int),
+		PackageID:               d.Get("package_id").(int),
 		Frequency:               d.Get("frequency").(int),
 		Locations:               expandIntSet(d.Get("locations").(*schema.Set)),
 		AvoidSimultaneousChecks: d.Get("avoid_simultaneous_checks").(bool),
@@ -170,6 +189,7 @@ func resourceDeviceRead(d *schema.ResourceData, meta interface{}) error {
 	// set state to detect drift
 	d.Set("name", device.Name)
 	d.Set("platform_id", device.PlatformID)
+	d.Set("package_id", device.PackageID)
 	d.Set("frequency", device.Frequency)
 	d.Set("locations", device.Locations)
 	d.Set("avoid_simultaneous_checks", device.AvoidSimultaneousChecks)
@@ -182,7 +202,7 @@ func resourceDeviceRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("scheduler_id", device.SchedulerID)
 
 	if device.Notifications != nil && device.Notifications.NotificationGroups != nil {
-		d.Set("notifications_groups", device.Notifications.NotificationGroups)
+		d.Set("notifications_groups", flattenNotificationsNotificationGroupList(&device.Notifications.NotificationGroups))
 	}
 
 	return nil
@@ -203,6 +223,7 @@ func resourceDeviceUpdate(d *schema.ResourceData, meta interface{}) error {
 		ID:                      deviceID,
 		Name:                    d.Get("name").(string),
 		PlatformID:              d.Get("platform_id").(int),
+		PackageID:               d.Get("package_id").(int),
 		Frequency:               d.Get("frequency").(int),
 		Locations:               expandIntSet(d.Get("locations").(*schema.Set)),
 		AvoidSimultaneousChecks: d.Get("avoid_simultaneous_checks").(bool),
@@ -256,6 +277,81 @@ func resourceDeviceDelete(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// resourceDeviceCustomizeDiff ... checks the locations of the device, and that the filter of the device can trigger on it
+func resourceDeviceCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := resourceDeviceCustomizeDiffLocations(d, meta); err != nil {
+		return err
+	}
+	return resourceDeviceCustomizeDiffFilter(d, meta)
+}
+
+// resourceDeviceCustomizeDiffLocations ... checks at plan time that the device has at least min_locations locations,
+//  and that they exist on its platform
+//
+// Deleted & unknown locations are errors, unavailable & restrictive locations too unless
+// ignore_plan_warnings is set. Only runs when the locations or the platform of the device change.
+func resourceDeviceCustomizeDiffLocations(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("locations") || !d.NewValueKnown("platform_id") || !d.NewValueKnown("min_locations") {
+		return nil
+	}
+
+	locations := expandIntSet(d.Get("locations").(*schema.Set))
+	if minLocations := d.Get("min_locations").(int); len(locations) < minLocations {
+		return fmt.Errorf("[Dotcom-Monitor] Device %q has %v locations, min_locations requires at least %v", d.Get("name").(string), len(locations), minLocations)
+	}
+
+	if d.Id() != "" && !d.HasChange("locations") && !d.HasChange("platform_id") {
+		return nil
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	platformID := d.Get("platform_id").(int)
+	problems, warnings, err := checkLocationsOnPlatform(meta.(*client.APIClient), platformID, locations)
+	if err != nil {
+		// the API rejects locations it does not know at apply time, don't fail the plan on platforms without a location list
+		log.Printf("[WARN] [Dotcom-Monitor] Device %q: unable to check locations on platform ID %v: %s", d.Get("name").(string), platformID, err)
+		return nil
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("[Dotcom-Monitor] Invalid locations for device %q on platform ID %v: %s", d.Get("name").(string), platformID, strings.Join(problems, "; "))
+	}
+
+	return checkPlanWarnings(d, fmt.Sprintf("Device %q", d.Get("name").(string)), warnings)
+}
+
+// resourceDeviceCustomizeDiffFilter ... fails the plan when the filter of the device can never trigger on it, unless ignore_plan_warnings is set
+//
+// Only runs when the filter or the locations of the device change, and only if the
+// filter already exists. The tasks of a device that is being created are not known yet.
+func resourceDeviceCustomizeDiffFilter(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("filter_id") || !d.NewValueKnown("locations") {
+		return nil
+	}
+	filterID := d.Get("filter_id").(int)
+	if filterID == 0 || (d.Id() != "" && !d.HasChange("filter_id") && !d.HasChange("locations")) {
+		return nil
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	deviceID, _ := strconv.Atoi(d.Id())
+	reasons, err := checkFilterOnDevice(meta.(*client.APIClient), filterID, deviceID, d.Get("locations").(*schema.Set).Len())
+	if err != nil {
+		return err
+	}
+
+	warnings := make([]string, 0)
+	for _, item := range reasons {
+		warnings = append(warnings, fmt.Sprintf("filter ID %v can never trigger, %s", filterID, item))
+	}
+
+	return checkPlanWarnings(d, fmt.Sprintf("Device %q", d.Get("name").(string)), warnings)
+}
+
 //////////////////////////////
 // Device helpers
 //////////////////////////////
@@ -276,3 +372,18 @@ func expandNotificationsNotificationGroupList(notificationGroups *schema.Set) []
 
 	return nnGroupList
 }
+
+// flattenNotificationsNotificationGroupList ... flattens device notification group objects to generic interface for state
+func flattenNotificationsNotificationGroupList(notificationGroups *[]client.NotificationsNotificationGroups) []map[string]interface{} {
+	l := make([]map[string]interface{}, 0)
+
+	for _, item := range *notificationGroups {
+		m := make(map[string]interface{})
+		m["id"] = item.ID
+		m["time_shift_min"] = item.TimeShiftMin
+
+		l = append(l, m)
+	}
+
+	return l
+}
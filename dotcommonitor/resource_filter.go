@@ -1,8 +1,10 @@
 package dotcommonitor
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -11,9 +13,6 @@ import (
 	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
 )
 
-const IgnoreErrorsCodesSeparator = ";"
-const IgnoreErrorsCodesRangeSeparator = "-"
-
 func resourceFilter() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceFilterCreate,
@@ -23,6 +22,15 @@ func resourceFilter() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceFilterCustomizeDiff,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceFilterV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceFilterStateUpgradeV0,
+			},
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -72,16 +80,44 @@ func resourceFilter() *schema.Resource {
 							Type:         schema.TypeString,
 							Required:     true,
 							StateFunc:    StateToLower,
-							ValidateFunc: validation.StringInSlice([]string{"Validation", "Runtime", "CustomScript", "Certificate", "Cryptographic", "Tcp", "Dns", "Udp", "Http", "Ftp", "Sftp", "Smtp", "Pop3", "Imap", "Icmp", "IcmpV6", "DnsBL", "Media", "Sip"}, true),
+							ValidateFunc: validation.StringInSlice(ignoreErrorsTypes, true),
 						},
 						"codes": {
-							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: validateIgnoreErrorsCodes,
+							Type:     schema.TypeSet,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntAtLeast(0),
+							},
+						},
+						"ranges": {
+							Type:       schema.TypeSet,
+							Optional:   true,
+							ConfigMode: schema.SchemaConfigModeAttr,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"from": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+									"to": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+								},
+							},
 						},
 					},
 				},
 			},
+			"assigned_device_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"ignore_plan_warnings": ignorePlanWarningsSchema(),
 		},
 	}
 }
@@ -145,8 +181,9 @@ func resourceFilterRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("name", filter.Name)
 	d.Set("description", filter.Description)
 	d.Set("rules", flattenFilterRules(&filter.Rules))
+	d.Set("assigned_device_ids", filter.AssignedTo)
 	if filter.Items != nil {
-		d.Set("ignore_errors", flattenIgnoreErrors(&filter.Items))
+		d.Set("ignore_errors", flattenIgnoreErrorsWithPrior(&filter.Items, d.Get("ignore_errors").(*schema.Set)))
 	}
 
 	return nil
@@ -168,8 +205,17 @@ func resourceFilterUpdate(d *schema.ResourceData, meta interface{}) error {
 	}
 	log.Printf("[Dotcom-Monitor] Attempting to update filter ID: %v", fmt.Sprint(filter.ID))
 
+	// keep the current assignments, they are managed by dotcommonitor_filter_assignment & the device filter_id argument
 	api := meta.(*client.APIClient)
-	err := api.UpdateFilter(filter)
+	current := &client.Filter{ID: filterID}
+	err := api.GetFilter(current)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get filter: %s", err)
+	}
+	filter.AssignedTo = current.AssignedTo
+
+	err = api.UpdateFilter(filter)
 
 	if err != nil {
 		mutex.Unlock()
@@ -247,29 +293,55 @@ func flattenFilterRules(rule *client.Rule) []map[string]interface{} {
 }
 
 // expandIgnoreErrors ... constructs dotcommonitor.Items structs based on the set of rules in the TF configuration
+// The codes & ranges are sent in their canonical order, see canonicalizeIgnoreErrorsCodes
 func expandIgnoreErrors(items *schema.Set) []client.Item {
 	itemList := make([]client.Item, len(items.List()))
 
 	for i, item := range items.List() {
 		var schemaMap = item.(map[string]interface{})
 
+		codes, ranges := canonicalizeIgnoreErrorsCodes(expandIgnoreErrorsCodes(schemaMap))
 		itemList[i] = client.Item{
 			ErrorType:         strings.ToLower(schemaMap["type"].(string)),
-			ErrorCodeToIgnore: expandErrorCodesString(schemaMap["codes"].(string)),
+			ErrorCodeToIgnore: buildErrorCodeToIgnore(codes, ranges),
 		}
 	}
 
 	return itemList
 }
 
+// expandIgnoreErrorsCodes ... reads the codes & ranges of an ignore_errors block
+func expandIgnoreErrorsCodes(schemaMap map[string]interface{}) ([]int, []client.ErrorCodeToIgnoreRange) {
+	codes := []int{}
+	if v, ok := schemaMap["codes"].(*schema.Set); ok {
+		codes = expandIntSet(v)
+	}
+
+	ranges := []client.ErrorCodeToIgnoreRange{}
+	if v, ok := schemaMap["ranges"].(*schema.Set); ok {
+		for _, item := range v.List() {
+			var rangeMap = item.(map[string]interface{})
+			ranges = append(ranges, client.ErrorCodeToIgnoreRange{
+				From: rangeMap["from"].(int),
+				To:   rangeMap["to"].(int),
+			})
+		}
+	}
+
+	return codes, ranges
+}
+
 // flattenIgnoreErrors ... flattens ignore errors objects to generic interface for state
 func flattenIgnoreErrors(ignoreErrors *[]client.Item) []map[string]interface{} {
 	l := make([]map[string]interface{}, 0)
 
 	for _, item := range *ignoreErrors {
+		codes, ranges := canonicalizeIgnoreErrorsCodes(parseErrorCodeToIgnore(item.ErrorCodeToIgnore))
+
 		m := make(map[string]interface{})
 		m["type"] = strings.ToLower(item.ErrorType)
-		m["codes"] = flattenIgnoreErrorsCodes(item.ErrorCodeToIgnore)
+		m["codes"] = codes
+		m["ranges"] = flattenIgnoreErrorsRanges(ranges)
 
 		l = append(l, m)
 	}
@@ -277,59 +349,152 @@ func flattenIgnoreErrors(ignoreErrors *[]client.Item) []map[string]interface{} {
 	return l
 }
 
-// expandErrorCodesString ... expands an error codes string to a list of interfaces
-func expandErrorCodesString(codes string) []interface{} {
-	var l []interface{}
+// flattenIgnoreErrorsWithPrior ... flattens ignore errors objects for state, keeping the configured codes & ranges of the types whose codes did not change
+// This keeps overlapping ranges & codes covered by a range in the configuration from showing up as a change on every plan
+func flattenIgnoreErrorsWithPrior(ignoreErrors *[]client.Item, prior *schema.Set) []map[string]interface{} {
+	l := flattenIgnoreErrors(ignoreErrors)
 
-	parts := strings.Split(codes, IgnoreErrorsCodesSeparator)
+	for _, m := range l {
+		for _, p := range prior.List() {
+			var schemaMap = p.(map[string]interface{})
+			if !strings.EqualFold(schemaMap["type"].(string), m["type"].(string)) {
+				continue
+			}
 
-	for _, item := range parts {
-		// first see if current item is a single error code
-		if i, err := strconv.Atoi(item); err != nil {
-			// not a single code, try a range
-			r := strings.Split(item, IgnoreErrorsCodesRangeSeparator)
-			if len(r) == 2 {
-				from, _ := strconv.Atoi(r[0])
-				to, _ := strconv.Atoi(r[1])
-				var errorRange = client.ErrorCodeToIgnoreRange{
-					From: from,
-					To:   to,
-				}
-				l = append(l, errorRange)
+			priorCodes, priorRanges := canonicalizeIgnoreErrorsCodes(expandIgnoreErrorsCodes(schemaMap))
+			if fmt.Sprint(priorCodes, priorRanges) == fmt.Sprint(m["codes"], expandIgnoreErrorsRanges(m["ranges"].([]map[string]interface{}))) {
+				m["codes"] = schemaMap["codes"]
+				m["ranges"] = schemaMap["ranges"]
 			}
-		} else {
-			// current item must be a single error code
-			l = append(l, i)
+			break
 		}
 	}
 
 	return l
 }
 
-// flattenIgnoreErrorsCodes ... flattens a list of interfaces into an error codes string
-func flattenIgnoreErrorsCodes(codes []interface{}) string {
-	var sb strings.Builder
+// flattenIgnoreErrorsRanges ... flattens error code ranges to generic interface for state
+func flattenIgnoreErrorsRanges(ranges []client.ErrorCodeToIgnoreRange) []map[string]interface{} {
+	l := make([]map[string]interface{}, 0)
+
+	for _, item := range ranges {
+		m := make(map[string]interface{})
+		m["from"] = item.From
+		m["to"] = item.To
+		l = append(l, m)
+	}
+
+	return l
+}
+
+// expandIgnoreErrorsRanges ... the inverse of flattenIgnoreErrorsRanges
+func expandIgnoreErrorsRanges(l []map[string]interface{}) []client.ErrorCodeToIgnoreRange {
+	ranges := []client.ErrorCodeToIgnoreRange{}
+
+	for _, m := range l {
+		ranges = append(ranges, client.ErrorCodeToIgnoreRange{From: m["from"].(int), To: m["to"].(int)})
+	}
+
+	return ranges
+}
+
+// canonicalizeIgnoreErrorsCodes ... sorts the codes & ranges, merges ranges that overlap or touch & drops codes already covered by a range
+func canonicalizeIgnoreErrorsCodes(codes []int, ranges []client.ErrorCodeToIgnoreRange) ([]int, []client.ErrorCodeToIgnoreRange) {
+	sortedRanges := make([]client.ErrorCodeToIgnoreRange, len(ranges))
+	copy(sortedRanges, ranges)
+	sort.Slice(sortedRanges, func(i, j int) bool {
+		if sortedRanges[i].From != sortedRanges[j].From {
+			return sortedRanges[i].From < sortedRanges[j].From
+		}
+		return sortedRanges[i].To < sortedRanges[j].To
+	})
+
+	mergedRanges := []client.ErrorCodeToIgnoreRange{}
+	for _, item := range sortedRanges {
+		last := len(mergedRanges) - 1
+		if last >= 0 && item.From <= mergedRanges[last].To+1 {
+			if item.To > mergedRanges[last].To {
+				mergedRanges[last].To = item.To
+			}
+			continue
+		}
+		mergedRanges = append(mergedRanges, item)
+	}
+
+	mergedCodes := []int{}
+	for _, code := range codes {
+		covered := false
+		for _, item := range mergedRanges {
+			if code >= item.From && code <= item.To {
+				covered = true
+				break
+			}
+		}
+		if !covered && !intInList(mergedCodes, code) {
+			mergedCodes = append(mergedCodes, code)
+		}
+	}
+	sort.Ints(mergedCodes)
+
+	return mergedCodes, mergedRanges
+}
+
+// buildErrorCodeToIgnore ... builds the API list of error codes to ignore, single codes first & then ranges
+func buildErrorCodeToIgnore(codes []int, ranges []client.ErrorCodeToIgnoreRange) []interface{} {
+	var l []interface{}
 
 	for _, item := range codes {
+		l = append(l, item)
+	}
+	for _, item := range ranges {
+		l = append(l, item)
+	}
+
+	return l
+}
+
+// parseErrorCodeToIgnore ... splits the API list of error codes to ignore into single codes & ranges
+func parseErrorCodeToIgnore(errorCodes []interface{}) ([]int, []client.ErrorCodeToIgnoreRange) {
+	codes := []int{}
+	ranges := []client.ErrorCodeToIgnoreRange{}
+
+	for _, item := range errorCodes {
 		// first see if current item is a single error code
 		if i, ok := item.(float64); !ok {
 			// not a single code, try a range
 			var schemaMap = item.(map[string]interface{})
-			r := client.ErrorCodeToIgnoreRange{
+			ranges = append(ranges, client.ErrorCodeToIgnoreRange{
 				From: int(schemaMap["From"].(float64)),
 				To:   int(schemaMap["To"].(float64)),
-			}
-			sb.WriteString(strconv.Itoa(r.From))
-			sb.WriteString(IgnoreErrorsCodesRangeSeparator)
-			sb.WriteString(strconv.Itoa(r.To))
-			sb.WriteString(IgnoreErrorsCodesSeparator)
-
+			})
 		} else {
 			// current item must be a single error code
-			sb.WriteString(strconv.Itoa(int(i)))
-			sb.WriteString(IgnoreErrorsCodesSeparator)
+			codes = append(codes, int(i))
+		}
+	}
+
+	return codes, ranges
+}
+
+// resourceFilterCustomizeDiff ... checks the ignore_errors codes & ranges at plan time, including against the error code catalog
+// Codes outside the catalog fail the plan unless ignore_plan_warnings is set
+func resourceFilterCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	var warnings []string
+	for _, item := range d.Get("ignore_errors").(*schema.Set).List() {
+		var schemaMap = item.(map[string]interface{})
+
+		codes, ranges := expandIgnoreErrorsCodes(schemaMap)
+		if len(codes) == 0 && len(ranges) == 0 {
+			return fmt.Errorf("[Dotcom-Monitor] ignore_errors of type %q must have at least one of codes or ranges", schemaMap["type"])
+		}
+		for _, r := range ranges {
+			if r.From >= r.To {
+				return fmt.Errorf("[Dotcom-Monitor] ignore_errors of type %q has range %v-%v, from must be smaller than to", schemaMap["type"], r.From, r.To)
+			}
 		}
+
+		warnings = append(warnings, checkIgnoreErrorsCodes(schemaMap["type"].(string), codes, ranges)...)
 	}
 
-	return strings.Trim(sb.String(), IgnoreErrorsCodesSeparator)
+	return checkPlanWarnings(d, fmt.Sprintf("Filter %q", d.Get("name").(string)), warnings)
 }
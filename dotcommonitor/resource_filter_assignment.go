@@ -0,0 +1,231 @@
+package dotcommonitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+func resourceFilterAssignment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceFilterAssignmentCreate,
+		Read:   resourceFilterAssignmentRead,
+		Update: resourceFilterAssignmentUpdate,
+		Delete: resourceFilterAssignmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceFilterAssignmentImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"filter_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"device_ids": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"force_reassign": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceFilterAssignmentCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	api := meta.(*client.APIClient)
+	filterID := d.Get("filter_id").(int)
+
+	deviceIDs := expandIntSet(d.Get("device_ids").(*schema.Set))
+	log.Printf("[Dotcom-Monitor] Filter assignment create configuration: filter ID %v, devices %v", filterID, deviceIDs)
+
+	err := applyFilterAssignment(api, filterID, []int{}, deviceIDs, d.Get("force_reassign").(bool))
+
+	if err != nil {
+		mutex.Unlock()
+		return err
+	}
+
+	log.Printf("[Dotcom-Monitor] Filter assignment successfully created - filter ID: %v", filterID)
+
+	// Set ID
+	d.SetId(fmt.Sprint(filterID))
+
+	mutex.Unlock()
+	return resourceFilterAssignmentRead(d, meta)
+}
+
+func resourceFilterAssignmentRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// Pull filter ID from state
+	filterID, _ := strconv.Atoi(d.Id())
+
+	filter := &client.Filter{}
+	filter.ID = filterID
+
+	api := meta.(*client.APIClient)
+	err := api.GetFilter(filter)
+
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get filter: %s", err)
+	}
+
+	// Check if filter exists before trying to read it
+	if !(filter.ID > 0) {
+		log.Printf("[Dotcom-Monitor] [WARNING] Filter does not exist, removing assignment ID %v from state", filterID)
+		d.SetId("")
+		return nil
+	}
+
+	// only the devices in state are managed by this resource, the importer seeds them
+	deviceIDs := expandIntSet(d.Get("device_ids").(*schema.Set))
+
+	// set state to detect drift
+	d.Set("filter_id", filter.ID)
+	d.Set("device_ids", intListIntersection(deviceIDs, filter.AssignedTo))
+
+	return nil
+}
+
+// resourceFilterAssignmentImport ... adopts every device currently assigned to the filter
+func resourceFilterAssignmentImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	filterID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("[Dotcom-Monitor] Invalid filter assignment import ID %q, must be <filter_id>", d.Id())
+	}
+
+	filter := &client.Filter{}
+	filter.ID = filterID
+
+	api := meta.(*client.APIClient)
+	if err := api.GetFilter(filter); err != nil {
+		return nil, fmt.Errorf("[Dotcom-Monitor] Failed to get filter: %s", err)
+	}
+	if !(filter.ID > 0) {
+		return nil, fmt.Errorf("[Dotcom-Monitor] Filter ID %v does not exist", filterID)
+	}
+
+	d.Set("device_ids", filter.AssignedTo)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceFilterAssignmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	api := meta.(*client.APIClient)
+	filterID, _ := strconv.Atoi(d.Id())
+
+	oldDevices, newDevices := d.GetChange("device_ids")
+
+	log.Printf("[Dotcom-Monitor] Attempting to update filter assignment ID: %v", filterID)
+
+	err := applyFilterAssignment(api, filterID, expandIntSet(oldDevices.(*schema.Set)), expandIntSet(newDevices.(*schema.Set)), d.Get("force_reassign").(bool))
+
+	if err != nil {
+		mutex.Unlock()
+		return err
+	}
+
+	log.Printf("[Dotcom-Monitor] Filter assignment ID: %v successfully updated", filterID)
+
+	mutex.Unlock()
+	return resourceFilterAssignmentRead(d, meta)
+}
+
+func resourceFilterAssignmentDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	api := meta.(*client.APIClient)
+	filterID, _ := strconv.Atoi(d.Id())
+
+	err := applyFilterAssignment(api, filterID, expandIntSet(d.Get("device_ids").(*schema.Set)), []int{}, true)
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+//////////////////////////////
+// Filter assignment helpers
+//////////////////////////////
+
+// applyFilterAssignment ... moves the filter assignments from the old devices to the new ones, leaving any other assignments of the filter alone
+//
+// Devices that are being added must not use another filter, e.g. through the
+// filter_id of a dotcommonitor_device resource, unless forceReassign is set.
+func applyFilterAssignment(api *client.APIClient, filterID int, oldDevices, newDevices []int, forceReassign bool) error {
+	addedDevices := intListDifference(newDevices, oldDevices)
+
+	if !forceReassign {
+		if err := detectFilterAssignmentConflicts(api, filterID, addedDevices); err != nil {
+			return err
+		}
+	}
+
+	// read the filter fresh so that assignments made elsewhere are kept
+	filter := &client.Filter{ID: filterID}
+	if err := api.GetFilter(filter); err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get filter: %s", err)
+	}
+	if !(filter.ID > 0) {
+		if len(newDevices) == 0 {
+			return nil // nothing left to unassign
+		}
+		return fmt.Errorf("[Dotcom-Monitor] Filter ID %v does not exist", filterID)
+	}
+
+	filter.AssignedTo = intListUnion(intListDifference(filter.AssignedTo, intListDifference(oldDevices, newDevices)), addedDevices)
+	log.Printf("[Dotcom-Monitor] Filter ID %v assignments: devices %v", filterID, filter.AssignedTo)
+
+	if err := api.UpdateFilter(filter); err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Failed to update filter assignments: %s", err)
+	}
+
+	return nil
+}
+
+// detectFilterAssignmentConflicts ... returns an error listing the devices that already use another filter
+func detectFilterAssignmentConflicts(api *client.APIClient, filterID int, deviceIDs []int) error {
+	var conflicts []string
+
+	for _, deviceID := range deviceIDs {
+		device := &client.Device{ID: deviceID}
+		if err := api.GetDevice(device); err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to get device: %s", err)
+		}
+		if !(device.ID > 0) {
+			return fmt.Errorf("[Dotcom-Monitor] Device ID %v does not exist", deviceID)
+		}
+		if device.FilterID != 0 && device.FilterID != filterID {
+			conflicts = append(conflicts, fmt.Sprintf("device ID %v uses filter ID %v", deviceID, device.FilterID))
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("[Dotcom-Monitor] Filter assignment conflicts: %v. Remove the filter_id argument of those devices, or set force_reassign to move them to filter ID %v", conflicts, filterID)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package dotcommonitor
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+// IgnoreErrorsCodesSeparator ... separates the codes of a legacy ignore_errors codes string, e.g. "404;500;400-499"
+const IgnoreErrorsCodesSeparator = ";"
+
+// IgnoreErrorsCodesRangeSeparator ... separates the two ends of a range in a legacy ignore_errors codes string
+const IgnoreErrorsCodesRangeSeparator = "-"
+
+// resourceFilterV0 ... the filter schema before ignore_errors codes were structured, only used to read old state
+func resourceFilterV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"rules": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"num_locations": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"num_tasks": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"num_minutes": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"owner_device_down": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"ignore_errors": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"codes": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"assigned_device_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+// resourceFilterStateUpgradeV0 ... converts the legacy ignore_errors codes string, e.g. "404;500;400-499", to codes & ranges
+func resourceFilterStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	ignoreErrors, ok := rawState["ignore_errors"].([]interface{})
+	if !ok {
+		return rawState, nil
+	}
+
+	for _, item := range ignoreErrors {
+		schemaMap, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		legacyCodes, _ := schemaMap["codes"].(string)
+
+		codes, ranges := parseErrorCodeToIgnoreString(legacyCodes)
+		codeList := make([]interface{}, 0)
+		for _, code := range codes {
+			codeList = append(codeList, code)
+		}
+		rangeList := make([]interface{}, 0)
+		for _, r := range ranges {
+			rangeList = append(rangeList, map[string]interface{}{"from": r.From, "to": r.To})
+		}
+
+		log.Printf("[Dotcom-Monitor] Upgrading filter ignore_errors codes %q to codes %v & ranges %v", legacyCodes, codes, ranges)
+		schemaMap["codes"] = codeList
+		schemaMap["ranges"] = rangeList
+	}
+
+	return rawState, nil
+}
+
+// parseErrorCodeToIgnoreString ... splits a legacy error codes string into single codes & ranges, skipping parts that cannot be parsed
+func parseErrorCodeToIgnoreString(s string) ([]int, []client.ErrorCodeToIgnoreRange) {
+	codes := []int{}
+	ranges := []client.ErrorCodeToIgnoreRange{}
+
+	for _, item := range strings.Split(s, IgnoreErrorsCodesSeparator) {
+		// first see if current item is a single error code
+		if i, err := strconv.Atoi(strings.TrimSpace(item)); err == nil {
+			codes = append(codes, i)
+			continue
+		}
+
+		// not a single code, try a range
+		r := strings.Split(item, IgnoreErrorsCodesRangeSeparator)
+		if len(r) != 2 {
+			continue
+		}
+		from, errFrom := strconv.Atoi(strings.TrimSpace(r[0]))
+		to, errTo := strconv.Atoi(strings.TrimSpace(r[1]))
+		if errFrom == nil && errTo == nil {
+			ranges = append(ranges, client.ErrorCodeToIgnoreRange{From: from, To: to})
+		}
+	}
+
+	return codes, ranges
+}
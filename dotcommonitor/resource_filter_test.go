@@ -0,0 +1,29 @@
+package dotcommonitor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceFilterCustomizeDiffUnknownCode(t *testing.T) {
+	config := func(ignorePlanWarnings bool) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":                 "example",
+			"rules":                []interface{}{map[string]interface{}{"num_locations": 1}},
+			"ignore_errors":        []interface{}{map[string]interface{}{"type": "Http", "codes": []interface{}{999}}},
+			"ignore_plan_warnings": ignorePlanWarnings,
+		})
+	}
+
+	_, err := resourceFilter().Diff(context.Background(), nil, config(false), nil)
+	if err == nil || !strings.Contains(err.Error(), "outside the standard Http range") {
+		t.Fatalf("error = %v, want the code outside the catalog", err)
+	}
+
+	if _, err := resourceFilter().Diff(context.Background(), nil, config(true), nil); err != nil {
+		t.Fatalf("unexpected error with ignore_plan_warnings: %s", err)
+	}
+}
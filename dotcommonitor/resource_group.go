@@ -1,15 +1,53 @@
 package dotcommonitor
 
 import (
+	"context"
+	"crypto/sha256"
 	"fmt"
 	"log"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
 )
 
+// groupAddressTypes ... delivery types accepted by the API for group addresses
+var groupAddressTypes = []string{"Email", "Phone", "Sms", "PagerDuty", "Slack", "Teams", "AlertOps", "Snmp"}
+
+// groupAddressTypeFields ... the address fields a delivery type requires, and the ones it also accepts
+// Key lists the non-secret fields identifying an address of the type, types without any are identified by a hash of their required fields
+type groupAddressTypeFields struct {
+	Required []string
+	Optional []string
+	Key      []string
+}
+
+// groupAddressFields ... the address fields of each delivery type, fields of other types must not be set
+var groupAddressFields = map[string]groupAddressTypeFields{
+	"Email":     {Required: []string{"address"}, Key: []string{"address"}},
+	"Phone":     {Required: []string{"number", "code"}, Key: []string{"number", "code"}},
+	"Sms":       {Required: []string{"number"}, Key: []string{"number"}},
+	"PagerDuty": {Required: []string{"integration_key"}},
+	"Slack":     {Required: []string{"webhook"}},
+	"Teams":     {Required: []string{"webhook"}},
+	"AlertOps":  {Required: []string{"integration_url"}},
+	"Snmp":      {Required: []string{"host", "community", "version"}, Optional: []string{"user_id"}, Key: []string{"host", "user_id"}},
+}
+
+// groupAddressSecretFields ... address fields holding secrets, which are redacted from logs & data sources
+var groupAddressSecretFields = []string{"integration_key", "integration_url", "webhook", "community"}
+
+const (
+	// groupAddressMaxAttempts ... how often an address edit is retried when the group changes concurrently
+	groupAddressMaxAttempts = 3
+	// groupAddressRetryDelay ... the wait before the second attempt of an address edit, growing linearly
+	groupAddressRetryDelay = 2 * time.Second
+)
+
 func resourceGroup() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceGroupCreate,
@@ -19,6 +57,7 @@ func resourceGroup() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: resourceGroupCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -28,82 +67,98 @@ func resourceGroup() *schema.Resource {
 			"scheduler_id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.IntAtLeast(0),
 			},
 			"addresses": {
 				Type:     schema.TypeSet,
 				Optional: true,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"type": {
-							Type:         schema.TypeString,
-							Required:     true,
-							ValidateFunc: validation.StringInSlice([]string{"Email", "Phone", "Sms", "PagerDuty", "Slack", "Teams", "AlertOps", "Snmp"}, true),
-						},
-						"template_id": {
-							Type:         schema.TypeInt,
-							Optional:     true,
-							ValidateFunc: validation.IntAtLeast(0),
-							Default:      0,
-						},
-						"address": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.StringLenBetween(1, 255),
-						},
-						"number": {
-							Type:     schema.TypeString,
-							Optional: true,
-							ValidateFunc: validation.All(
-								validation.StringLenBetween(1, 16),
-								validateGroupAddressNumber(),
-							),
-						},
-						"code": {
-							Type:     schema.TypeString,
-							Optional: true,
-							ValidateFunc: validation.All(
-								validation.StringLenBetween(3, 3),
-								validateGroupAddressCode(),
-							),
-						},
-						"integration_key": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.StringLenBetween(1, 255),
-						},
-						"integration_url": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.StringLenBetween(1, 255),
-						},
-						"webhook": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.StringLenBetween(1, 255),
-						},
-						"community": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.StringLenBetween(1, 255),
-						},
-						"host": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.StringLenBetween(1, 255),
-						},
-						"user_id": {
-							Type:     schema.TypeInt,
-							Optional: true,
-						},
-						"version": {
-							Type:         schema.TypeString,
-							Optional:     true,
-							ValidateFunc: validation.StringInSlice([]string{"V1", "V2c", "V3"}, true),
-						},
-					},
+					Schema: groupAddressSchema(),
 				},
 			},
+			"exclusive": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+		},
+	}
+}
+
+// groupAddressSchema ... the fields of a group address, shared by dotcommonitor_group & dotcommonitor_group_address
+func groupAddressSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringInSlice(groupAddressTypes, true),
+			StateFunc:    StateGroupAddressType,
+		},
+		"template_id": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(0),
+			Default:      0,
+		},
+		"address": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringLenBetween(1, 255),
+		},
+		"number": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ValidateFunc: validation.All(
+				validation.StringLenBetween(1, 16),
+				validateGroupAddressNumber(),
+			),
+		},
+		"code": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ValidateFunc: validation.All(
+				validation.StringLenBetween(3, 3),
+				validateGroupAddressCode(),
+			),
+		},
+		"integration_key": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.StringLenBetween(1, 255),
+		},
+		"integration_url": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.StringLenBetween(1, 255),
+		},
+		"webhook": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.StringLenBetween(1, 255),
+		},
+		"community": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.StringLenBetween(1, 255),
+		},
+		"host": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringLenBetween(1, 255),
+		},
+		"user_id": {
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+		"version": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{"V1", "V2c", "V3"}, true),
 		},
 	}
 }
@@ -169,7 +224,12 @@ func resourceGroupRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("scheduler_id", group.SchedulerID)
 
 	if group.Addresses != nil {
-		d.Set("addresses", group.Addresses)
+		addresses := group.Addresses
+		if !d.Get("exclusive").(bool) {
+			// only the addresses in state are managed by this resource
+			addresses = filterGroupAddressesByKey(group.Addresses, expandGroupAddresses(d.Get("addresses").(*schema.Set)))
+		}
+		d.Set("addresses", flattenGroupAddresses(&addresses))
 	}
 
 	return nil
@@ -194,6 +254,20 @@ func resourceGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 	log.Printf("[Dotcom-Monitor] Attempting to update group ID: %v", fmt.Sprint(group.ID))
 
 	api := meta.(*client.APIClient)
+
+	// keep the addresses managed elsewhere, e.g. by dotcommonitor_group_address
+	if !d.Get("exclusive").(bool) {
+		current := &client.Group{ID: groupID}
+		if err := api.GetGroup(current); err != nil {
+			mutex.Unlock()
+			return fmt.Errorf("[Dotcom-Monitor] Failed to get group: %s", err)
+		}
+
+		oldAddresses, _ := d.GetChange("addresses")
+		remove := filterGroupAddressesByKey(current.Addresses, expandGroupAddresses(oldAddresses.(*schema.Set)))
+		group.Addresses, _ = mergeGroupAddresses(current.Addresses, remove, addresses)
+	}
+
 	err := api.UpdateGroup(group)
 
 	if err != nil {
@@ -231,6 +305,25 @@ func resourceGroupDelete(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// resourceGroupCustomizeDiff ... checks at plan time that every address sets the fields its delivery type requires, and none of the fields of other types
+func resourceGroupCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// an address with an unknown field makes the whole set unknown, so it can only be checked once known
+	if !d.NewValueKnown("addresses") {
+		return nil
+	}
+
+	var problems []string
+	for _, item := range d.Get("addresses").(*schema.Set).List() {
+		problems = append(problems, checkGroupAddressFields(item.(map[string]interface{}), nil)...)
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("[Dotcom-Monitor] Invalid group addresses: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
 //////////////////////////////
 // Group helpers
 //////////////////////////////
@@ -240,39 +333,288 @@ func expandGroupAddresses(schemaAddresses *schema.Set) []client.Addresses {
 	addressList := make([]client.Addresses, len(schemaAddresses.List()))
 
 	for i, item := range schemaAddresses.List() {
-		var schemaMap = item.(map[string]interface{})
+		addressList[i] = expandGroupAddress(item.(map[string]interface{}))
+	}
+
+	return addressList
+}
+
+// expandGroupAddress .. constructs a client.Addresses struct based on a single address in the TF configuration
+func expandGroupAddress(schemaMap map[string]interface{}) client.Addresses {
+	address := client.Addresses{
+		Type:       canonicalGroupAddressType(schemaMap["type"].(string)),
+		TemplateID: schemaMap["template_id"].(int),
+	}
+
+	// Populate rest of the struct with the appropriate fields
+	switch address.Type {
+	case "Email":
+		address.Address = schemaMap["address"].(string)
+	case "Phone":
+		address.Number = schemaMap["number"].(string)
+		address.Code = schemaMap["code"].(string)
+	case "Sms":
+		address.Number = schemaMap["number"].(string)
+	case "PagerDuty":
+		address.IntegrationKey = schemaMap["integration_key"].(string)
+	case "AlertOps":
+		address.IntegrationURL = schemaMap["integration_url"].(string)
+	case "Slack":
+		address.WebHook = schemaMap["webhook"].(string)
+	case "Teams":
+		address.WebHook = schemaMap["webhook"].(string)
+	case "Snmp":
+		address.Community = schemaMap["community"].(string)
+		address.Host = schemaMap["host"].(string)
+		address.UserID = schemaMap["user_id"].(int)
+		address.Version = schemaMap["version"].(string)
+	}
+
+	return address
+}
+
+// flattenGroupAddresses ... flattens group address objects to generic interface for state
+func flattenGroupAddresses(addresses *[]client.Addresses) []map[string]interface{} {
+	l := make([]map[string]interface{}, 0)
+
+	for _, item := range *addresses {
+		m := make(map[string]interface{})
+		m["type"] = item.Type
+		m["template_id"] = item.TemplateID
+		m["address"] = item.Address
+		m["number"] = item.Number
+		m["code"] = item.Code
+		m["integration_key"] = item.IntegrationKey
+		m["integration_url"] = item.IntegrationURL
+		m["webhook"] = item.WebHook
+		m["community"] = item.Community
+		m["host"] = item.Host
+		m["user_id"] = item.UserID
+		m["version"] = item.Version
+
+		l = append(l, m)
+	}
+
+	return l
+}
+
+// checkGroupAddressFields ... returns the required fields the address is missing & the fields it sets that its delivery type does not accept
+// Fields in unknown are not known until apply, they count as set for the required fields & are never reported as not accepted
+func checkGroupAddressFields(schemaMap map[string]interface{}, unknown map[string]bool) []string {
+	if unknown["type"] {
+		return nil
+	}
+	addressType := canonicalGroupAddressType(schemaMap["type"].(string))
+	fields, ok := groupAddressFields[addressType]
+	if !ok {
+		return nil // unknown types are rejected by the schema
+	}
+
+	accepted := make(map[string]bool)
+	var missing, forbidden []string
+	for _, field := range fields.Required {
+		accepted[field] = true
+		if !unknown[field] && !groupAddressFieldIsSet(schemaMap[field]) {
+			missing = append(missing, field)
+		}
+	}
+	for _, field := range fields.Optional {
+		accepted[field] = true
+	}
+	for _, other := range groupAddressFields {
+		for _, field := range append(append([]string{}, other.Required...), other.Optional...) {
+			if !accepted[field] && !unknown[field] && groupAddressFieldIsSet(schemaMap[field]) {
+				accepted[field] = true // only report each field once
+				forbidden = append(forbidden, field)
+			}
+		}
+	}
+	sort.Strings(forbidden)
+
+	var problems []string
+	if len(missing) > 0 {
+		problems = append(problems, fmt.Sprintf("%s address requires %s", addressType, strings.Join(missing, ", ")))
+	}
+	if len(forbidden) > 0 {
+		problems = append(problems, fmt.Sprintf("%s address does not accept %s", addressType, strings.Join(forbidden, ", ")))
+	}
+
+	return problems
+}
 
-		addressList[i] = client.Addresses{
-			Type:       schemaMap["type"].(string),
-			TemplateID: schemaMap["template_id"].(int),
+// groupAddressFieldIsSet ... returns whether an address field has a non-zero value
+func groupAddressFieldIsSet(v interface{}) bool {
+	switch value := v.(type) {
+	case string:
+		return value != ""
+	case int:
+		return value != 0
+	case map[string]interface{}:
+		return len(value) > 0
+	}
+	return false
+}
+
+// canonicalGroupAddressType ... returns the delivery type as accepted by the API, matched case-insensitively
+func canonicalGroupAddressType(addressType string) string {
+	for _, item := range groupAddressTypes {
+		if strings.EqualFold(item, addressType) {
+			return item
+		}
+	}
+	return addressType
+}
+
+// groupAddressKeys ... identifies each address within the list by its type & non-secret key fields
+// Addresses of types without key fields only have secret fields, they are identified by a SHA-256 hash of their required fields
+func groupAddressKeys(addresses []client.Addresses) []string {
+	keys := make([]string, len(addresses))
+
+	for i, item := range addresses {
+		addressType := canonicalGroupAddressType(item.Type)
+		m := flattenGroupAddresses(&[]client.Addresses{item})[0]
+
+		fields := groupAddressFields[addressType].Key
+		if len(fields) == 0 {
+			values := make([]string, 0)
+			for _, field := range groupAddressFields[addressType].Required {
+				values = append(values, fmt.Sprint(m[field]))
+			}
+			keys[i] = fmt.Sprintf("%s\x00#%x", addressType, sha256.Sum256([]byte(strings.Join(values, "\x00"))))
+			continue
 		}
 
-		// Populate rest of the struct with the appropriate fields
-		switch addressList[i].Type {
-		case "Email":
-			addressList[i].Address = schemaMap["address"].(string)
-		case "Phone":
-			addressList[i].Number = schemaMap["number"].(string)
-			addressList[i].Code = schemaMap["code"].(string)
-		case "Sms":
-			addressList[i].Number = schemaMap["number"].(string)
-		case "PagerDuty":
-			addressList[i].IntegrationKey = schemaMap["integration_key"].(string)
-		case "AlertOps":
-			addressList[i].IntegrationURL = schemaMap["integration_url"].(string)
-		case "Slack":
-			addressList[i].WebHook = schemaMap["webhook"].(string)
-		case "Teams":
-			addressList[i].WebHook = schemaMap["webhook"].(string)
-		case "Snmp":
-			addressList[i].Community = schemaMap["community"].(string)
-			addressList[i].Host = schemaMap["host"].(string)
-			addressList[i].UserID = schemaMap["user_id"].(int)
-			addressList[i].Version = schemaMap["version"].(string)
-			// case "Script":
-			// 	addressList[i].Message = schemaMap["message"].(string)
+		parts := []string{addressType}
+		for _, field := range fields {
+			parts = append(parts, fmt.Sprint(m[field]))
 		}
+		keys[i] = strings.Join(parts, "\x00")
 	}
 
-	return addressList
+	return keys
+}
+
+// filterGroupAddressesByKey ... returns the addresses that have the key of one of the wanted addresses
+func filterGroupAddressesByKey(addresses []client.Addresses, wanted []client.Addresses) []client.Addresses {
+	keys := make(map[string]bool)
+	for _, key := range groupAddressKeys(wanted) {
+		keys[key] = true
+	}
+
+	l := make([]client.Addresses, 0)
+	for i, key := range groupAddressKeys(addresses) {
+		if keys[key] {
+			l = append(l, addresses[i])
+		}
+	}
+
+	return l
+}
+
+// groupAddressesEqual ... compares two addresses, matching the type case-insensitively
+func groupAddressesEqual(a, b client.Addresses) bool {
+	a.Type, b.Type = canonicalGroupAddressType(a.Type), canonicalGroupAddressType(b.Type)
+	return a == b
+}
+
+// groupAddressInList ... checks if an equal address is in the list of addresses
+func groupAddressInList(address client.Addresses, addresses []client.Addresses) bool {
+	for _, item := range addresses {
+		if groupAddressesEqual(address, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeGroupAddresses ... returns the current addresses without the removed ones & with the added addresses, and whether they differ from the current ones
+// An added address replaces a current one with its key, or takes the place of a removed address of the same type,
+// so that the order of the addresses is kept
+func mergeGroupAddresses(current []client.Addresses, remove []client.Addresses, add []client.Addresses) ([]client.Addresses, bool) {
+	pending := append([]client.Addresses{}, add...)
+	pendingKeys := groupAddressKeys(pending)
+	replace := make(map[string]bool)
+	for _, key := range pendingKeys {
+		replace[key] = true
+	}
+
+	merged := make([]client.Addresses, 0, len(current)+len(add))
+	changed := false
+	for i, key := range groupAddressKeys(current) {
+		item := current[i]
+		removed := groupAddressInList(item, remove)
+		if !removed && !replace[key] {
+			// an added address that is already there is not added again
+			for k := range pending {
+				if groupAddressesEqual(pending[k], item) {
+					pending = append(pending[:k], pending[k+1:]...)
+					pendingKeys = append(pendingKeys[:k], pendingKeys[k+1:]...)
+					break
+				}
+			}
+			merged = append(merged, item)
+			continue
+		}
+		changed = changed || !groupAddressInList(item, add)
+
+		// fill the place with the address replacing it, or any added address of the same type
+		j := -1
+		for k := range pending {
+			if pendingKeys[k] == key && replace[key] {
+				j = k
+				break
+			}
+			if j < 0 && removed && canonicalGroupAddressType(pending[k].Type) == canonicalGroupAddressType(item.Type) {
+				j = k
+			}
+		}
+		if j >= 0 {
+			merged = append(merged, pending[j])
+			pending = append(pending[:j], pending[j+1:]...)
+			pendingKeys = append(pendingKeys[:j], pendingKeys[j+1:]...)
+		}
+	}
+	for _, item := range add {
+		changed = changed || !groupAddressInList(item, current)
+	}
+
+	return append(merged, pending...), changed
+}
+
+// editGroupAddresses ... removes the given addresses from the group & adds the given addresses, keeping all other addresses
+// It returns the addresses of the group after the edit
+//
+// The group is read fresh for every attempt and checked afterwards, so addresses
+// added or removed elsewhere at the same time are kept. If the result is missing
+// the edit, e.g. because of a concurrent write, the edit is retried.
+func editGroupAddresses(api *client.APIClient, groupID int, remove []client.Addresses, add []client.Addresses) ([]client.Addresses, error) {
+	for attempt := 1; ; attempt++ {
+		group := &client.Group{ID: groupID}
+		if err := api.GetGroup(group); err != nil {
+			return nil, fmt.Errorf("[Dotcom-Monitor] Failed to get group: %s", err)
+		}
+		if !(group.ID > 0) {
+			if len(add) == 0 {
+				return nil, nil // nothing left to remove the addresses from
+			}
+			return nil, fmt.Errorf("[Dotcom-Monitor] Group ID %v does not exist", groupID)
+		}
+
+		merged, changed := mergeGroupAddresses(group.Addresses, remove, add)
+		if !changed {
+			return group.Addresses, nil // already in the wanted state
+		}
+		if attempt > groupAddressMaxAttempts {
+			return nil, fmt.Errorf("[Dotcom-Monitor] Group ID %v kept changing, gave up editing its addresses after %v attempts", groupID, groupAddressMaxAttempts)
+		}
+		if attempt > 1 {
+			log.Printf("[Dotcom-Monitor] Group ID %v was changed concurrently, retrying address edit (attempt %v)", groupID, attempt)
+			time.Sleep(time.Duration(attempt-1) * groupAddressRetryDelay)
+		}
+
+		group.Addresses = merged
+		if err := api.UpdateGroup(group); err != nil {
+			return nil, fmt.Errorf("[Dotcom-Monitor] Failed to update group addresses: %s", err)
+		}
+	}
 }
@@ -0,0 +1,283 @@
+package dotcommonitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+func resourceGroupAddress() *schema.Resource {
+	s := groupAddressSchema()
+	s["group_id"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Required:     true,
+		ForceNew:     true,
+		ValidateFunc: validation.IntAtLeast(1),
+	}
+
+	return &schema.Resource{
+		Create: resourceGroupAddressCreate,
+		Read:   resourceGroupAddressRead,
+		Update: resourceGroupAddressUpdate,
+		Delete: resourceGroupAddressDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGroupAddressImport,
+		},
+		CustomizeDiff: resourceGroupAddressCustomizeDiff,
+		Schema:        s,
+	}
+}
+
+func resourceGroupAddressCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	api := meta.(*client.APIClient)
+	groupID := d.Get("group_id").(int)
+
+	address := expandGroupAddress(groupAddressSchemaMap(d))
+	log.Printf("[Dotcom-Monitor] Group address create configuration: group ID %v, type %v", groupID, address.Type)
+
+	// refuse to take over an address that already exists on the group
+	group := &client.Group{ID: groupID}
+	if err := api.GetGroup(group); err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get group: %s", err)
+	}
+	if !(group.ID > 0) {
+		mutex.Unlock()
+		return fmt.Errorf("[Dotcom-Monitor] Group ID %v does not exist", groupID)
+	}
+	if len(filterGroupAddressesByKey(group.Addresses, []client.Addresses{address})) > 0 {
+		mutex.Unlock()
+		return fmt.Errorf("[Dotcom-Monitor] Group ID %v already has this %s address, import it instead", groupID, address.Type)
+	}
+
+	addresses, err := editGroupAddresses(api, groupID, nil, []client.Addresses{address})
+
+	if err != nil {
+		mutex.Unlock()
+		return err
+	}
+
+	log.Printf("[Dotcom-Monitor] Group address successfully created - group ID: %v", groupID)
+
+	// Set ID
+	id, ok := groupAddressIDIn(groupID, addresses, address)
+	if !ok {
+		mutex.Unlock()
+		return fmt.Errorf("[Dotcom-Monitor] Group ID %v is missing the created %s address", groupID, address.Type)
+	}
+	d.SetId(id)
+
+	mutex.Unlock()
+	return resourceGroupAddressRead(d, meta)
+}
+
+func resourceGroupAddressRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// Pull group ID from state
+	groupID, _ := strconv.Atoi(strings.SplitN(d.Id(), ":", 2)[0])
+
+	group := &client.Group{}
+	group.ID = groupID
+
+	api := meta.(*client.APIClient)
+	err := api.GetGroup(group)
+
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get group: %s", err)
+	}
+
+	// Check if group & address exist before trying to read them
+	if !(group.ID > 0) {
+		log.Printf("[Dotcom-Monitor] [WARNING] Group does not exist, removing address ID %v from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	address := findGroupAddressByID(groupID, group.Addresses, d.Id())
+	if address == nil {
+		log.Printf("[Dotcom-Monitor] [WARNING] Group address does not exist, removing ID %v from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// set state to detect drift
+	m := flattenGroupAddresses(&[]client.Addresses{*address})[0]
+	d.Set("group_id", group.ID)
+	for k, v := range m {
+		d.Set(k, v)
+	}
+
+	return nil
+}
+
+func resourceGroupAddressUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	api := meta.(*client.APIClient)
+	groupID := d.Get("group_id").(int)
+
+	address := expandGroupAddress(groupAddressSchemaMap(d))
+	log.Printf("[Dotcom-Monitor] Attempting to update group address ID: %v", d.Id())
+
+	// the address being replaced is the one the ID was built from
+	var remove []client.Addresses
+	group := &client.Group{ID: groupID}
+	if err := api.GetGroup(group); err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get group: %s", err)
+	}
+	if item := findGroupAddressByID(groupID, group.Addresses, d.Id()); item != nil {
+		remove = append(remove, *item)
+	}
+
+	addresses, err := editGroupAddresses(api, groupID, remove, []client.Addresses{address})
+
+	if err != nil {
+		mutex.Unlock()
+		return err
+	}
+
+	log.Printf("[Dotcom-Monitor] Group address ID: %v successfully updated", d.Id())
+
+	id, ok := groupAddressIDIn(groupID, addresses, address)
+	if !ok {
+		mutex.Unlock()
+		return fmt.Errorf("[Dotcom-Monitor] Group ID %v is missing the updated %s address", groupID, address.Type)
+	}
+	d.SetId(id)
+
+	mutex.Unlock()
+	return resourceGroupAddressRead(d, meta)
+}
+
+func resourceGroupAddressDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	api := meta.(*client.APIClient)
+	groupID := d.Get("group_id").(int)
+
+	group := &client.Group{ID: groupID}
+	if err := api.GetGroup(group); err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get group: %s", err)
+	}
+
+	if address := findGroupAddressByID(groupID, group.Addresses, d.Id()); address != nil {
+		if _, err := editGroupAddresses(api, groupID, []client.Addresses{*address}, nil); err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// resourceGroupAddressImport ... imports an address by "<group_id>:<type>:<value>"
+// The value is the first key field of the type, or the position among the addresses of the type for types without key fields
+func resourceGroupAddressImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	parts := strings.SplitN(d.Id(), ":", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("[Dotcom-Monitor] Invalid group address import ID %q, must be <group_id>:<type>:<value>", d.Id())
+	}
+	groupID, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("[Dotcom-Monitor] Invalid group ID %q in group address import ID", parts[0])
+	}
+	addressType := canonicalGroupAddressType(parts[1])
+	fields, ok := groupAddressFields[addressType]
+	if !ok {
+		return nil, fmt.Errorf("[Dotcom-Monitor] Invalid address type %q in group address import ID", parts[1])
+	}
+
+	group := &client.Group{ID: groupID}
+	api := meta.(*client.APIClient)
+	if err := api.GetGroup(group); err != nil {
+		return nil, fmt.Errorf("[Dotcom-Monitor] Failed to get group: %s", err)
+	}
+
+	position := 0
+	keys := groupAddressKeys(group.Addresses)
+	for i, item := range group.Addresses {
+		if canonicalGroupAddressType(item.Type) != addressType {
+			continue
+		}
+		m := flattenGroupAddresses(&[]client.Addresses{item})[0]
+		if (len(fields.Key) > 0 && fmt.Sprint(m[fields.Key[0]]) == parts[2]) || (len(fields.Key) == 0 && fmt.Sprint(position) == parts[2]) {
+			d.SetId(groupAddressID(groupID, keys[i]))
+			d.Set("group_id", groupID)
+			return []*schema.ResourceData{d}, nil
+		}
+		position++
+	}
+
+	if len(fields.Key) == 0 {
+		return nil, fmt.Errorf("[Dotcom-Monitor] Group ID %v has no %s address at position %q", groupID, addressType, parts[2])
+	}
+	return nil, fmt.Errorf("[Dotcom-Monitor] Group ID %v has no %s address with %s %q", groupID, addressType, fields.Key[0], parts[2])
+}
+
+// resourceGroupAddressCustomizeDiff ... checks at plan time that the address sets the fields its delivery type requires, and none of the fields of other types
+func resourceGroupAddressCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	schemaMap := make(map[string]interface{})
+	unknown := make(map[string]bool)
+	for k := range groupAddressSchema() {
+		schemaMap[k] = d.Get(k)
+		unknown[k] = !d.NewValueKnown(k)
+	}
+
+	if problems := checkGroupAddressFields(schemaMap, unknown); len(problems) > 0 {
+		return fmt.Errorf("[Dotcom-Monitor] Invalid group address: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+//////////////////////////////
+// Group address helpers
+//////////////////////////////
+
+// groupAddressID ... builds the ID of a group address, "<group_id>:<hash of the address key>"
+func groupAddressID(groupID int, key string) string {
+	return fmt.Sprintf("%v:%v", groupID, schema.HashString(key))
+}
+
+// groupAddressIDIn ... builds the ID of an address, checking that the addresses of its group have it
+func groupAddressIDIn(groupID int, addresses []client.Addresses, address client.Addresses) (string, bool) {
+	if len(filterGroupAddressesByKey(addresses, []client.Addresses{address})) == 0 {
+		return "", false
+	}
+	return groupAddressID(groupID, groupAddressKeys([]client.Addresses{address})[0]), true
+}
+
+// findGroupAddressByID ... returns the address of the group with the given ID, nil if there is none
+func findGroupAddressByID(groupID int, addresses []client.Addresses, id string) *client.Addresses {
+	for i, key := range groupAddressKeys(addresses) {
+		if groupAddressID(groupID, key) == id {
+			return &addresses[i]
+		}
+	}
+	return nil
+}
+
+// groupAddressSchemaMap ... collects the address fields of the resource into the same shape as an element of the group addresses set
+func groupAddressSchemaMap(d *schema.ResourceData) map[string]interface{} {
+	schemaMap := make(map[string]interface{})
+	for k := range groupAddressSchema() {
+		schemaMap[k] = d.Get(k)
+	}
+	return schemaMap
+}
@@ -0,0 +1,79 @@
+package dotcommonitor
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+func TestResourceGroupAddressCustomizeDiffUnknownField(t *testing.T) {
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+	}{
+		{"unknown required field", map[string]interface{}{"group_id": 1, "type": "PagerDuty", "integration_key": unknownValue}},
+		{"unknown type", map[string]interface{}{"group_id": 1, "type": unknownValue, "webhook": "https://example.com"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := terraform.NewResourceConfigRaw(tc.config)
+			if _, err := resourceGroupAddress().Diff(context.Background(), nil, config, nil); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestResourceGroupAddressCustomizeDiffMissingField(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"group_id": 1, "type": "PagerDuty", "webhook": unknownValue})
+
+	_, err := resourceGroupAddress().Diff(context.Background(), nil, config, nil)
+	if err == nil || !strings.Contains(err.Error(), "PagerDuty address requires integration_key") {
+		t.Fatalf("error = %v, want the missing integration_key", err)
+	}
+	if strings.Contains(err.Error(), "webhook") {
+		t.Fatalf("error = %v, want the unknown webhook left out", err)
+	}
+}
+
+func TestEditGroupAddressesKeepsIDsOfOtherAddresses(t *testing.T) {
+	first := client.Addresses{Type: "Slack", WebHook: "https://hooks.slack.com/services/1"}
+	second := client.Addresses{Type: "Slack", WebHook: "https://hooks.slack.com/services/2"}
+	fake := newFakeAPI(t, map[string]interface{}{
+		"group/4": client.Group{ID: 4, Name: "example", Addresses: []client.Addresses{first, second}},
+	})
+	secondID, _ := groupAddressIDIn(4, []client.Addresses{first, second}, second)
+
+	addresses, err := editGroupAddresses(fake.client(t), 4, []client.Addresses{first}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if address := findGroupAddressByID(4, addresses, secondID); address == nil || address.WebHook != second.WebHook {
+		t.Fatalf("address with ID %q = %v, want %v", secondID, address, second)
+	}
+}
+
+func TestEditGroupAddressesRemovesLastAddress(t *testing.T) {
+	address := client.Addresses{Type: "Slack", WebHook: "https://hooks.slack.com/services/1"}
+	fake := newFakeAPI(t, map[string]interface{}{
+		"group/4": client.Group{ID: 4, Name: "example", Addresses: []client.Addresses{address}},
+	})
+
+	if _, err := editGroupAddresses(fake.client(t), 4, []client.Addresses{address}, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if update := fake.lastUpdate("group/4"); !strings.Contains(update, `"Addresses":[]`) {
+		t.Errorf("update = %s, want an explicit empty Addresses", update)
+	}
+	var group client.Group
+	fake.get(t, "group/4", &group)
+	if len(group.Addresses) != 0 {
+		t.Errorf("addresses = %v, want none", group.Addresses)
+	}
+}
@@ -0,0 +1,105 @@
+package dotcommonitor
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+// unknownValue ... the value the SDK uses for unknown values in a raw configuration
+const unknownValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func TestResourceGroupCustomizeDiffUnknownAddress(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "example",
+		"addresses": []interface{}{
+			map[string]interface{}{"type": "PagerDuty", "integration_key": unknownValue},
+		},
+	})
+
+	if _, err := resourceGroup().Diff(context.Background(), nil, config, nil); err != nil {
+		t.Fatalf("unexpected error for an unknown required field: %s", err)
+	}
+}
+
+func TestResourceGroupCustomizeDiffMissingField(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "example",
+		"addresses": []interface{}{
+			map[string]interface{}{"type": "PagerDuty"},
+		},
+	})
+
+	_, err := resourceGroup().Diff(context.Background(), nil, config, nil)
+	if err == nil || !strings.Contains(err.Error(), "PagerDuty address requires integration_key") {
+		t.Fatalf("error = %v, want the missing integration_key", err)
+	}
+}
+
+func TestGroupAddressKeysLeaveOutSecrets(t *testing.T) {
+	addresses := []client.Addresses{
+		{Type: "Slack", WebHook: "https://hooks.slack.com/services/secret-1"},
+		{Type: "Email", Address: "ops@example.com"},
+		{Type: "Slack", WebHook: "https://hooks.slack.com/services/secret-2"},
+		{Type: "Snmp", Host: "snmp.example.com", Community: "secret-3", Version: "V2c"},
+	}
+
+	keys := groupAddressKeys(addresses)
+	if keys[1] != "Email\x00ops@example.com" || keys[3] != "Snmp\x00snmp.example.com\x000" {
+		t.Fatalf("groupAddressKeys = %q, want the non-secret key fields", keys)
+	}
+	if !strings.HasPrefix(keys[0], "Slack\x00#") || keys[0] == keys[2] {
+		t.Fatalf("groupAddressKeys = %q, want distinct Slack keys", keys)
+	}
+	for _, key := range keys {
+		if strings.Contains(key, "secret") {
+			t.Fatalf("key %q contains a secret", key)
+		}
+	}
+}
+
+func TestGroupAddressKeysDoNotDependOnPosition(t *testing.T) {
+	first := client.Addresses{Type: "Slack", WebHook: "https://hooks.slack.com/services/1"}
+	second := client.Addresses{Type: "Slack", WebHook: "https://hooks.slack.com/services/2"}
+
+	before := groupAddressKeys([]client.Addresses{first, second})
+	after := groupAddressKeys([]client.Addresses{second})
+	if before[1] != after[0] {
+		t.Fatalf("key of the second Slack address changed from %q to %q when the first was removed", before[1], after[0])
+	}
+}
+
+func TestMergeGroupAddressesKeepsPositions(t *testing.T) {
+	first := client.Addresses{Type: "Slack", WebHook: "https://hooks.slack.com/services/1"}
+	second := client.Addresses{Type: "Slack", WebHook: "https://hooks.slack.com/services/2"}
+	updated := client.Addresses{Type: "Slack", WebHook: "https://hooks.slack.com/services/3"}
+	email := client.Addresses{Type: "Email", Address: "ops@example.com"}
+	current := []client.Addresses{first, email, second}
+
+	merged, changed := mergeGroupAddresses(current, []client.Addresses{first}, []client.Addresses{updated})
+	if want := []client.Addresses{updated, email, second}; !changed || !reflect.DeepEqual(merged, want) {
+		t.Fatalf("mergeGroupAddresses = %v, %v, want %v, true", merged, changed, want)
+	}
+
+	// a retry against the edited group must not remove anything else
+	again, changed := mergeGroupAddresses(merged, []client.Addresses{first}, []client.Addresses{updated})
+	if changed || !reflect.DeepEqual(again, merged) {
+		t.Fatalf("second mergeGroupAddresses = %v, %v, want %v, false", again, changed, merged)
+	}
+}
+
+func TestMergeGroupAddressesReplacesByKey(t *testing.T) {
+	email := client.Addresses{Type: "Email", Address: "ops@example.com"}
+	slack := client.Addresses{Type: "Slack", WebHook: "https://hooks.slack.com/services/1"}
+	retemplated := client.Addresses{Type: "Email", Address: "ops@example.com", TemplateID: 5}
+	added := client.Addresses{Type: "Slack", WebHook: "https://hooks.slack.com/services/2"}
+
+	merged, changed := mergeGroupAddresses([]client.Addresses{email, slack}, nil, []client.Addresses{retemplated, added})
+	if want := []client.Addresses{retemplated, slack, added}; !changed || !reflect.DeepEqual(merged, want) {
+		t.Fatalf("mergeGroupAddresses = %v, %v, want %v, true", merged, changed, want)
+	}
+}
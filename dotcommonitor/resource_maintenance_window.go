@@ -0,0 +1,395 @@
+package dotcommonitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+// maintenanceWindowMaxAttempts ... how many times a scheduler edit is retried when a concurrent edit overwrote it
+const maintenanceWindowMaxAttempts = 5
+
+// maintenanceWindowRetryDelay ... how long to wait before retrying a scheduler edit, multiplied by the attempt number
+const maintenanceWindowRetryDelay = 2 * time.Second
+
+func resourceMaintenanceWindow() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceMaintenanceWindowCreate,
+		Read:   resourceMaintenanceWindowRead,
+		Delete: resourceMaintenanceWindowDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMaintenanceWindowImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"scheduler_id": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				ExactlyOneOf: []string{"scheduler_id", "device_ids"},
+			},
+			"device_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+			"name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ValidateFunc:  validation.StringLenBetween(1, 255),
+				ConflictsWith: []string{"scheduler_id"},
+			},
+			"start": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "now",
+				ValidateFunc:     validateExcludedTimeIntervalTimestamp,
+				DiffSuppressFunc: suppressEquivalentExcludedTimeInterval,
+			},
+			"duration_minutes": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"from": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"to": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"dedicated_scheduler": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"previous_scheduler_ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+		},
+	}
+}
+
+func resourceMaintenanceWindowCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	api := meta.(*client.APIClient)
+
+	from, err := parseExcludedTimeIntervalTimestamp(d.Get("start").(string), time.Now())
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Invalid maintenance window start: %s", err)
+	}
+	window := client.DateTimeInterval{
+		From: from.Unix() * 1000, // API time is in milliseconds
+		To:   from.Add(time.Duration(d.Get("duration_minutes").(int))*time.Minute).Unix() * 1000,
+	}
+	log.Printf("[Dotcom-Monitor] Maintenance window create configuration: %v", window)
+
+	var schedulerID int
+	if v, ok := d.GetOk("scheduler_id"); ok {
+		// add the window to the existing scheduler
+		schedulerID = v.(int)
+		err = editMaintenanceWindowScheduler(api, schedulerID, window, true)
+		if err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to add maintenance window to scheduler ID %v: %s", schedulerID, err)
+		}
+	} else {
+		// create a dedicated scheduler for the window & move the devices to it
+		schedulerID, err = createMaintenanceWindowScheduler(d, api, window)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[Dotcom-Monitor] Maintenance window successfully created on scheduler ID: %v", schedulerID)
+
+	// Set ID
+	d.SetId(maintenanceWindowID(schedulerID, window))
+	d.Set("scheduler_id", schedulerID)
+	d.Set("dedicated_scheduler", len(d.Get("device_ids").(*schema.Set).List()) > 0)
+	d.Set("from", convertUnixToExcludedTimeIntervalFormat(window.From))
+	d.Set("to", convertUnixToExcludedTimeIntervalFormat(window.To))
+
+	return nil
+}
+
+func resourceMaintenanceWindowRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// Pull scheduler ID & window from state
+	schedulerID, window, err := parseMaintenanceWindowID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	scheduler := &client.Scheduler{}
+	scheduler.ID = schedulerID
+
+	api := meta.(*client.APIClient)
+	err = api.GetScheduler(scheduler)
+
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get scheduler: %s", err)
+	}
+
+	// Check if the scheduler still has the window before trying to read it
+	if !(scheduler.ID > 0) || !schedulerDateTimeIntervalInList(window, scheduler.ExcludedTimeIntervals) {
+		log.Printf("[Dotcom-Monitor] [WARNING] Maintenance window does not exist, removing ID %v from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	// set state to detect drift
+	d.Set("scheduler_id", schedulerID)
+	d.Set("from", convertUnixToExcludedTimeIntervalFormat(window.From))
+	d.Set("to", convertUnixToExcludedTimeIntervalFormat(window.To))
+	d.Set("duration_minutes", int((window.To-window.From)/1000/60))
+	if _, ok := d.GetOk("start"); !ok {
+		d.Set("start", convertUnixToExcludedTimeIntervalFormat(window.From)) // imported
+	}
+	if d.Get("dedicated_scheduler").(bool) {
+		// only drop the devices in state that were moved off the dedicated scheduler, never adopt others
+		d.Set("name", scheduler.Name)
+		d.Set("device_ids", intListIntersection(expandIntSet(d.Get("device_ids").(*schema.Set)), scheduler.AssignedTo.Devices))
+	}
+
+	return nil
+}
+
+func resourceMaintenanceWindowDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// Pull scheduler ID & window from state
+	schedulerID, window, err := parseMaintenanceWindowID(d.Id())
+	if err != nil {
+		return err
+	}
+
+	api := meta.(*client.APIClient)
+
+	if d.Get("dedicated_scheduler").(bool) {
+		// dedicated scheduler, even if every device has since moved off it
+		// move the devices back to their previous schedulers, then drop the dedicated scheduler
+		if err := restoreMaintenanceWindowDevices(api, schedulerID, d.Get("previous_scheduler_ids").(map[string]interface{})); err != nil {
+			return err
+		}
+
+		err = api.DeleteScheduler(&client.Scheduler{ID: schedulerID})
+		if err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to delete maintenance window scheduler: %s", err)
+		}
+	} else {
+		err = editMaintenanceWindowScheduler(api, schedulerID, window, false)
+		if err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to remove maintenance window from scheduler ID %v: %s", schedulerID, err)
+		}
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceMaintenanceWindowImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// a dedicated scheduler is marked with a ":dedicated" suffix, as the scheduler itself does not record it
+	id := strings.TrimSuffix(d.Id(), ":dedicated")
+	dedicated := id != d.Id()
+	schedulerID, _, err := parseMaintenanceWindowID(id)
+	if err != nil {
+		return nil, err
+	}
+	d.SetId(id)
+	d.Set("dedicated_scheduler", dedicated)
+
+	if dedicated {
+		scheduler := &client.Scheduler{ID: schedulerID}
+		api := meta.(*client.APIClient)
+		if err := api.GetScheduler(scheduler); err != nil {
+			return nil, fmt.Errorf("[Dotcom-Monitor] Failed to get scheduler: %s", err)
+		}
+		if !(scheduler.ID > 0) {
+			return nil, fmt.Errorf("[Dotcom-Monitor] Scheduler ID %v does not exist", schedulerID)
+		}
+		// the previous schedulers of the devices are not known, so none are recorded
+		d.Set("device_ids", scheduler.AssignedTo.Devices)
+		d.Set("name", scheduler.Name)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+//////////////////////////////
+// Maintenance window helpers
+//////////////////////////////
+
+// maintenanceWindowID ... builds the ID of a maintenance window from its scheduler & times, e.g. "123:1625875200:1625878800"
+func maintenanceWindowID(schedulerID int, window client.DateTimeInterval) string {
+	return fmt.Sprintf("%v:%v:%v", schedulerID, window.From/1000, window.To/1000)
+}
+
+// parseMaintenanceWindowID ... splits a maintenance window ID into the scheduler ID & the window
+func parseMaintenanceWindowID(id string) (int, client.DateTimeInterval, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) == 3 {
+		schedulerID, errScheduler := strconv.Atoi(parts[0])
+		from, errFrom := strconv.ParseInt(parts[1], 10, 64)
+		to, errTo := strconv.ParseInt(parts[2], 10, 64)
+		if errScheduler == nil && errFrom == nil && errTo == nil {
+			return schedulerID, client.DateTimeInterval{From: from * 1000, To: to * 1000}, nil
+		}
+	}
+
+	return 0, client.DateTimeInterval{}, fmt.Errorf("[Dotcom-Monitor] Invalid maintenance window ID %q, must be in the format <scheduler_id>:<from_unix>:<to_unix>", id)
+}
+
+// editMaintenanceWindowScheduler ... adds the window to, or removes it from, the scheduler without touching its other intervals
+//
+// The scheduler is read fresh before every edit and checked again afterwards, so
+// changes made to it by others in the meantime are kept. If another edit overwrote
+// ours, the edit is retried on top of it.
+func editMaintenanceWindowScheduler(api *client.APIClient, schedulerID int, window client.DateTimeInterval, add bool) error {
+	for attempt := 1; ; attempt++ {
+		scheduler := &client.Scheduler{ID: schedulerID}
+		if err := api.GetScheduler(scheduler); err != nil {
+			return err
+		}
+		if !(scheduler.ID > 0) {
+			if add {
+				return fmt.Errorf("scheduler does not exist")
+			}
+			return nil // nothing left to remove the window from
+		}
+
+		if schedulerDateTimeIntervalInList(window, scheduler.ExcludedTimeIntervals) == add {
+			return nil // already in the wanted state
+		}
+		if attempt > maintenanceWindowMaxAttempts {
+			return fmt.Errorf("scheduler kept changing, gave up after %v attempts", maintenanceWindowMaxAttempts)
+		}
+		if attempt > 1 {
+			log.Printf("[Dotcom-Monitor] Scheduler ID %v was changed concurrently, retrying maintenance window edit (attempt %v)", schedulerID, attempt)
+			time.Sleep(time.Duration(attempt-1) * maintenanceWindowRetryDelay)
+		}
+
+		if add {
+			scheduler.ExcludedTimeIntervals = append(scheduler.ExcludedTimeIntervals, window)
+		} else {
+			intervals := make([]client.DateTimeInterval, 0)
+			for _, item := range scheduler.ExcludedTimeIntervals {
+				if !schedulerDateTimeIntervalInList(item, []client.DateTimeInterval{window}) {
+					intervals = append(intervals, item)
+				}
+			}
+			scheduler.ExcludedTimeIntervals = intervals
+		}
+
+		if err := api.UpdateScheduler(scheduler); err != nil {
+			return err
+		}
+	}
+}
+
+// createMaintenanceWindowScheduler ... creates a scheduler for the window assigned to the devices & records their previous schedulers
+//
+// When all of the devices share a scheduler, its intervals are copied so that the
+// devices are monitored the same way outside of the window.
+func createMaintenanceWindowScheduler(d *schema.ResourceData, api *client.APIClient, window client.DateTimeInterval) (int, error) {
+	deviceIDs := expandIntSet(d.Get("device_ids").(*schema.Set))
+
+	previous := make(map[string]interface{})
+	sharedSchedulerID := -1
+	for _, deviceID := range deviceIDs {
+		device := &client.Device{ID: deviceID}
+		if err := api.GetDevice(device); err != nil {
+			return 0, fmt.Errorf("[Dotcom-Monitor] Failed to get device: %s", err)
+		}
+		if !(device.ID > 0) {
+			return 0, fmt.Errorf("[Dotcom-Monitor] Device ID %v does not exist", deviceID)
+		}
+		previous[fmt.Sprint(deviceID)] = device.SchedulerID
+
+		if sharedSchedulerID == -1 {
+			sharedSchedulerID = device.SchedulerID
+		} else if sharedSchedulerID != device.SchedulerID {
+			sharedSchedulerID = 0
+		}
+	}
+
+	name := d.Get("name").(string)
+	if name == "" {
+		name = fmt.Sprintf("maintenance-window-%v", time.Unix(window.From/1000, 0).UTC().Format("20060102T1504Z"))
+	}
+	scheduler := &client.Scheduler{
+		Name:        name,
+		Description: fmt.Sprintf("Maintenance window from %v to %v", convertUnixToExcludedTimeIntervalFormat(window.From), convertUnixToExcludedTimeIntervalFormat(window.To)),
+		AssignedTo:  client.AssignedTo{Devices: deviceIDs},
+	}
+
+	if sharedSchedulerID > 0 {
+		shared := &client.Scheduler{ID: sharedSchedulerID}
+		if err := api.GetScheduler(shared); err != nil {
+			return 0, fmt.Errorf("[Dotcom-Monitor] Failed to get scheduler: %s", err)
+		}
+		scheduler.WeeklyIntervals = shared.WeeklyIntervals
+		scheduler.ExcludedTimeIntervals = shared.ExcludedTimeIntervals
+	}
+	scheduler.ExcludedTimeIntervals = append(scheduler.ExcludedTimeIntervals, window)
+
+	log.Printf("[Dotcom-Monitor] Maintenance window scheduler create configuration: %v", scheduler)
+
+	if err := api.CreateScheduler(scheduler); err != nil {
+		return 0, fmt.Errorf("[Dotcom-Monitor] Failed to create maintenance window scheduler: %s", err)
+	}
+	d.Set("name", name)
+	d.Set("previous_scheduler_ids", previous)
+
+	return scheduler.ID, nil
+}
+
+// restoreMaintenanceWindowDevices ... moves devices still on the maintenance window scheduler back to the scheduler they had before
+func restoreMaintenanceWindowDevices(api *client.APIClient, schedulerID int, previous map[string]interface{}) error {
+	for key, value := range previous {
+		deviceID, _ := strconv.Atoi(key)
+
+		device := &client.Device{ID: deviceID}
+		if err := api.GetDevice(device); err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to get device: %s", err)
+		}
+		if !(device.ID > 0) || device.SchedulerID != schedulerID {
+			continue // deleted, or moved to another scheduler since
+		}
+
+		device.SchedulerID = value.(int)
+		if err := api.UpdateDevice(device); err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to restore the scheduler of device ID %v: %s", deviceID, err)
+		}
+		log.Printf("[Dotcom-Monitor] Device ID %v moved back to scheduler ID %v", deviceID, device.SchedulerID)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package dotcommonitor
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+func TestEditMaintenanceWindowSchedulerRemovesLastWindow(t *testing.T) {
+	window := client.DateTimeInterval{From: 1617235200, To: 1617238800}
+	fake := newFakeAPI(t, map[string]interface{}{
+		"scheduler/1": client.Scheduler{ID: 1, Name: "example", ExcludedTimeIntervals: []client.DateTimeInterval{window}},
+	})
+
+	if err := editMaintenanceWindowScheduler(fake.client(t), 1, window, false); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if update := fake.lastUpdate("scheduler/1"); !strings.Contains(update, `"Date_Time_Intervals":[]`) {
+		t.Errorf("update = %s, want an explicit empty Date_Time_Intervals", update)
+	}
+	var scheduler client.Scheduler
+	fake.get(t, "scheduler/1", &scheduler)
+	if len(scheduler.ExcludedTimeIntervals) != 0 {
+		t.Errorf("excluded intervals = %v, want none", scheduler.ExcludedTimeIntervals)
+	}
+}
+
+func TestResourceMaintenanceWindowImportDedicated(t *testing.T) {
+	fake := newFakeAPI(t, map[string]interface{}{
+		"scheduler/7": client.Scheduler{ID: 7, Name: "deploy", AssignedTo: client.AssignedTo{Devices: []int{12, 11}}},
+	})
+	resource := resourceMaintenanceWindow()
+
+	cases := map[string]bool{
+		"7:1625875200:1625877000":           false,
+		"7:1625875200:1625877000:dedicated": true,
+	}
+	for id, dedicated := range cases {
+		d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+		d.SetId(id)
+		result, err := resource.Importer.StateContext(context.Background(), d, fake.client(t))
+		if err != nil {
+			t.Fatalf("import %q: unexpected error: %s", id, err)
+		}
+
+		imported := result[0]
+		if imported.Id() != "7:1625875200:1625877000" {
+			t.Errorf("import %q: ID = %q, want the ID without the suffix", id, imported.Id())
+		}
+		if imported.Get("dedicated_scheduler").(bool) != dedicated {
+			t.Errorf("import %q: dedicated_scheduler = %v, want %v", id, !dedicated, dedicated)
+		}
+		want := []int{}
+		if dedicated {
+			want = []int{11, 12}
+		}
+		got := expandIntSet(imported.Get("device_ids").(*schema.Set))
+		sort.Ints(got)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("import %q: device_ids = %v, want %v", id, got, want)
+		}
+	}
+}
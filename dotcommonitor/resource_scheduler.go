@@ -1,12 +1,16 @@
 package dotcommonitor
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
@@ -14,6 +18,9 @@ import (
 
 const schedulerExcludedTimeIntervalLayout = "2006-01-02T15:04Z"
 
+// schedulerRecurringExclusionDefaultHorizon ... how far ahead recurring exclusions are expanded by default
+const schedulerRecurringExclusionDefaultHorizon = "90d"
+
 func resourceScheduler() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceSchedulerCreate,
@@ -23,6 +30,11 @@ func resourceScheduler() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: customdiff.All(
+			resourceSchedulerCustomizeDiffIntervals,
+			resourceSchedulerCustomizeDiffRecurringExclusions,
+			resourceSchedulerCustomizeDiffTimezoneOffset,
+		),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -33,6 +45,11 @@ func resourceScheduler() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"timezone": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateSchedulerTimezone,
+			},
 			"weekly_intervals": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -71,21 +88,97 @@ func resourceScheduler() *schema.Resource {
 			"excluded_time_intervals": {
 				Type:     schema.TypeSet,
 				Optional: true,
+				Set:      hashSchedulerExcludedTimeInterval,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"from": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateFunc:     validateExcludedTimeIntervalTimestamp,
+							DiffSuppressFunc: suppressEquivalentExcludedTimeInterval,
+						},
+						"to": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateFunc:     validateExcludedTimeIntervalTimestamp,
+							DiffSuppressFunc: suppressEquivalentExcludedTimeInterval,
+						},
+						"from_resolved": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"to_resolved": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"recurring_exclusion": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rrule": {
 							Type:         schema.TypeString,
 							Required:     true,
-							ValidateFunc: validateExcludedTimeIntervalTimestamp,
+							ValidateFunc: validateRecurrenceRule,
 						},
-						"to": {
+						"start": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateFunc:     validateRecurringExclusionStart,
+							DiffSuppressFunc: suppressEquivalentExcludedTimeInterval,
+						},
+						"duration": {
 							Type:         schema.TypeString,
 							Required:     true,
-							ValidateFunc: validateExcludedTimeIntervalTimestamp,
+							ValidateFunc: validateRecurringExclusionDuration,
+						},
+						"horizon": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      schedulerRecurringExclusionDefaultHorizon,
+							ValidateFunc: validateRecurringExclusionDuration,
+						},
+					},
+				},
+			},
+			"weekly_coverage_minutes": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"assigned_devices": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"assigned_groups": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"timezone_offset_minutes": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"recurring_exclusion_windows": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"from": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"to": {
+							Type:     schema.TypeString,
+							Computed: true,
 						},
 					},
 				},
 			},
+			"ignore_plan_warnings": ignorePlanWarningsSchema(),
 		},
 	}
 }
@@ -108,12 +201,33 @@ func resourceSchedulerCreate(d *schema.ResourceData, meta interface{}) error {
 	for _, item := range scheduler.WeeklyIntervals {
 		invalidDays := detectInvalidSchedulerWeeklyIntervalDays(item.Days)
 		if len(invalidDays) > 0 {
+			mutex.Unlock()
 			return fmt.Errorf("[Dotcom-Monitor] Invalid WeeklyInterval Days provided: %v", invalidDays)
 		}
 	}
 
+	// convert weekly intervals from the configured timezone to the API reference zone
+	now := time.Now()
+	weeklyIntervals, err := convertWeeklyIntervalsToReference(scheduler.WeeklyIntervals, d.Get("timezone").(string), now)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("[Dotcom-Monitor] Failed to convert weekly intervals: %s", err)
+	}
+	scheduler.WeeklyIntervals = weeklyIntervals
+	offset, _ := schedulerTimezoneOffset(d.Get("timezone").(string), now)
+	d.Set("timezone_offset_minutes", offset)
+
+	// add the windows of the recurring exclusions to the one-off excluded time intervals
+	excludedTimeIntervals := scheduler.ExcludedTimeIntervals
+	windows, err := getSchedulerRecurringExclusionWindows(d)
+	if err != nil {
+		mutex.Unlock()
+		return err
+	}
+	scheduler.ExcludedTimeIntervals = append(scheduler.ExcludedTimeIntervals, windows...)
+
 	// create the scheduler
-	err := api.CreateScheduler(scheduler)
+	err = api.CreateScheduler(scheduler)
 
 	if err != nil {
 		mutex.Unlock()
@@ -125,6 +239,8 @@ func resourceSchedulerCreate(d *schema.ResourceData, meta interface{}) error {
 	// Set ID
 	strID := fmt.Sprint(scheduler.ID)
 	d.SetId(strID)
+	d.Set("excluded_time_intervals", resolveSchedulerExcludedTimeIntervals(d.Get("excluded_time_intervals").(*schema.Set), excludedTimeIntervals))
+	d.Set("recurring_exclusion_windows", flattenSchedulerExcludedTimeIntervalsList(&windows))
 
 	mutex.Unlock()
 	return resourceSchedulerRead(d, meta)
@@ -157,11 +273,33 @@ func resourceSchedulerRead(d *schema.ResourceData, meta interface{}) error {
 	// set state to detect drift
 	d.Set("name", scheduler.Name)
 	d.Set("description", scheduler.Description)
+	d.Set("assigned_devices", scheduler.AssignedTo.Devices)
+	d.Set("assigned_groups", scheduler.AssignedTo.Groups)
 	if scheduler.WeeklyIntervals != nil {
-		d.Set("weekly_intervals", flattenSchedulerWeeklyIntervalsList(&scheduler.WeeklyIntervals))
+		// re-derive the local times using the offset in effect now, so DST changes are picked up
+		timezone := d.Get("timezone").(string)
+		now := time.Now()
+		weeklyIntervals, err := convertWeeklyIntervalsFromReference(scheduler.WeeklyIntervals, timezone, now)
+		if err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to convert weekly intervals: %s", err)
+		}
+
+		// keep the intervals as configured while they still convert to what the API has,
+		//  the conversion merges adjacent intervals & regroups days
+		prior := expandSchedulerWeeklyIntervalsList(d.Get("weekly_intervals").(*schema.Set))
+		if len(prior) > 0 {
+			if expected, err := convertWeeklyIntervalsToReference(prior, timezone, now); err == nil && weeklyIntervalsEquivalent(expected, scheduler.WeeklyIntervals) {
+				weeklyIntervals = prior
+			}
+		}
+		d.Set("weekly_intervals", flattenSchedulerWeeklyIntervalsList(&weeklyIntervals))
 	}
+	d.Set("weekly_coverage_minutes", weeklyCoverageMinutes(scheduler.WeeklyIntervals))
 	if scheduler.ExcludedTimeIntervals != nil {
-		d.Set("excluded_time_intervals", flattenSchedulerExcludedTimeIntervalsList(&scheduler.ExcludedTimeIntervals))
+		// separate the windows generated from the recurring exclusions from the one-off excluded time intervals
+		excludedTimeIntervals, windows := splitSchedulerRecurringExclusionWindows(d, scheduler.ExcludedTimeIntervals)
+		d.Set("excluded_time_intervals", flattenSchedulerExcludedTimeIntervalsSet(&excludedTimeIntervals, d.Get("excluded_time_intervals").(*schema.Set)))
+		d.Set("recurring_exclusion_windows", flattenSchedulerExcludedTimeIntervalsList(&windows))
 	}
 
 	return nil
@@ -186,14 +324,44 @@ func resourceSchedulerUpdate(d *schema.ResourceData, meta interface{}) error {
 	for _, item := range scheduler.WeeklyIntervals {
 		invalidDays := detectInvalidSchedulerWeeklyIntervalDays(item.Days)
 		if len(invalidDays) > 0 {
+			mutex.Unlock()
 			return fmt.Errorf("[Dotcom-Monitor] Invalid WeeklyInterval Days provided: %v", invalidDays)
 		}
 	}
 
+	// convert weekly intervals from the configured timezone to the API reference zone
+	now := time.Now()
+	weeklyIntervals, err := convertWeeklyIntervalsToReference(scheduler.WeeklyIntervals, d.Get("timezone").(string), now)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("[Dotcom-Monitor] Failed to convert weekly intervals: %s", err)
+	}
+	scheduler.WeeklyIntervals = weeklyIntervals
+	offset, _ := schedulerTimezoneOffset(d.Get("timezone").(string), now)
+	d.Set("timezone_offset_minutes", offset)
+
+	// add the windows of the recurring exclusions to the one-off excluded time intervals
+	excludedTimeIntervals := scheduler.ExcludedTimeIntervals
+	windows, err := getSchedulerRecurringExclusionWindows(d)
+	if err != nil {
+		mutex.Unlock()
+		return err
+	}
+	scheduler.ExcludedTimeIntervals = append(scheduler.ExcludedTimeIntervals, windows...)
+
 	log.Printf("[Dotcom-Monitor] Attempting to update scheduler ID: %v", fmt.Sprint(scheduler.ID))
 
+	// keep the current assignments, they are managed by dotcommonitor_scheduler_assignment & the device/group scheduler_id arguments
 	api := meta.(*client.APIClient)
-	err := api.UpdateScheduler(scheduler)
+	current := &client.Scheduler{ID: schedulerID}
+	err = api.GetScheduler(current)
+	if err != nil {
+		mutex.Unlock()
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get scheduler: %s", err)
+	}
+	scheduler.AssignedTo = current.AssignedTo
+
+	err = api.UpdateScheduler(scheduler)
 
 	if err != nil {
 		mutex.Unlock()
@@ -201,6 +369,8 @@ func resourceSchedulerUpdate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	log.Printf("[Dotcom-Monitor] Scheduler ID: %v successfully updated", fmt.Sprint(scheduler.ID))
+	d.Set("excluded_time_intervals", resolveSchedulerExcludedTimeIntervals(d.Get("excluded_time_intervals").(*schema.Set), excludedTimeIntervals))
+	d.Set("recurring_exclusion_windows", flattenSchedulerExcludedTimeIntervalsList(&windows))
 
 	mutex.Unlock()
 	d.Partial(false)
@@ -230,6 +400,77 @@ func resourceSchedulerDelete(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// resourceSchedulerCustomizeDiffIntervals ... checks the weekly & excluded time intervals for contradictions at plan time & plans the weekly coverage
+// Contradictions fail the plan, as do redundant intervals unless ignore_plan_warnings is set; intervals in the past are only logged
+func resourceSchedulerCustomizeDiffIntervals(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("weekly_intervals") || !d.NewValueKnown("excluded_time_intervals") {
+		return d.SetNewComputed("weekly_coverage_minutes")
+	}
+
+	weeklyIntervals := expandSchedulerWeeklyIntervalsList(d.Get("weekly_intervals").(*schema.Set))
+	excludedTimeIntervals := expandSchedulerExcludedTimeIntervalsList(d.Get("excluded_time_intervals").(*schema.Set))
+
+	errs, warnings := analyzeWeeklyIntervals(weeklyIntervals)
+	excludedErrs, excludedWarnings := analyzeExcludedTimeIntervals(excludedTimeIntervals, time.Now())
+	errs = append(errs, excludedErrs...)
+
+	// an interval passing must not fail the plan of an unchanged scheduler
+	for _, item := range excludedWarnings {
+		log.Printf("[WARN] [Dotcom-Monitor] Scheduler %q: %s", d.Get("name").(string), item)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("[Dotcom-Monitor] Invalid scheduler intervals: %s", strings.Join(errs, "; "))
+	}
+	if err := checkPlanWarnings(d, fmt.Sprintf("Scheduler %q", d.Get("name").(string)), warnings); err != nil {
+		return err
+	}
+
+	coverage := weeklyCoverageMinutes(weeklyIntervals)
+	if old, _ := d.GetChange("weekly_coverage_minutes"); d.Id() == "" || old.(int) != coverage {
+		return d.SetNew("weekly_coverage_minutes", coverage)
+	}
+
+	return nil
+}
+
+// resourceSchedulerCustomizeDiffRecurringExclusions ... plans an update whenever the windows of the recurring exclusions have moved on, e.g. a window passed or a new one came within the horizon
+func resourceSchedulerCustomizeDiffRecurringExclusions(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("recurring_exclusion") || !d.NewValueKnown("timezone") {
+		return d.SetNewComputed("recurring_exclusion_windows")
+	}
+
+	windows, err := expandSchedulerRecurringExclusions(d.Get("recurring_exclusion").([]interface{}), d.Get("timezone").(string), time.Now())
+	if err != nil {
+		// a value that is only known at apply time, e.g. a start computed by another resource
+		return d.SetNewComputed("recurring_exclusion_windows")
+	}
+
+	oldWindows, _ := d.GetChange("recurring_exclusion_windows")
+	if schedulerDateTimeIntervalsEqual(expandSchedulerDateTimeIntervalsList(oldWindows.([]interface{})), windows) {
+		return nil
+	}
+
+	log.Printf("[Dotcom-Monitor] Scheduler recurring exclusion windows changed, planning %v windows", len(windows))
+	return d.SetNew("recurring_exclusion_windows", flattenSchedulerExcludedTimeIntervalsList(&windows))
+}
+
+// resourceSchedulerCustomizeDiffTimezoneOffset ... plans an update when the offset of the timezone changed since the weekly intervals were converted, e.g. on a daylight saving time transition
+func resourceSchedulerCustomizeDiffTimezoneOffset(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("timezone") {
+		return d.SetNewComputed("timezone_offset_minutes")
+	}
+
+	offset, err := schedulerTimezoneOffset(d.Get("timezone").(string), time.Now())
+	if err != nil {
+		return nil // reported by the timezone validation
+	}
+	if old, _ := d.GetChange("timezone_offset_minutes"); d.Id() == "" || old.(int) != offset {
+		return d.SetNew("timezone_offset_minutes", offset)
+	}
+
+	return nil
+}
+
 //////////////////////////////
 // Scheduler helpers
 //////////////////////////////
@@ -272,21 +513,49 @@ func flattenSchedulerWeeklyIntervalsList(weeklyIntervals *[]client.WeeklyInterva
 }
 
 // expandSchedulerExcludedTimeIntervalsList ... constructs a list of dotcommonitor.DateTimeInterval structs based on the set of excluded_time_intervals in the TF configuration
+// Relative expressions keep the time they resolved to when first applied, so unrelated updates do not move them
 func expandSchedulerExcludedTimeIntervalsList(excludedTimeIntervals *schema.Set) []client.DateTimeInterval {
 	etList := make([]client.DateTimeInterval, len(excludedTimeIntervals.List()))
+	now := time.Now()
 
 	for i, item := range excludedTimeIntervals.List() {
 		var schemaMap = item.(map[string]interface{})
 
 		etList[i] = client.DateTimeInterval{
-			From: convertExcludedTimeIntervalFormatToUnix(schemaMap["from"].(string)),
-			To:   convertExcludedTimeIntervalFormatToUnix(schemaMap["to"].(string)),
+			From: expandExcludedTimeIntervalTimestamp(schemaMap["from"].(string), schemaMap["from_resolved"], now),
+			To:   expandExcludedTimeIntervalTimestamp(schemaMap["to"].(string), schemaMap["to_resolved"], now),
 		}
 	}
 
 	return etList
 }
 
+// expandExcludedTimeIntervalTimestamp ... converts a timestamp to Unix epoch time, reusing the previously resolved time of a relative expression if there is one
+func expandExcludedTimeIntervalTimestamp(s string, resolved interface{}, now time.Time) int64 {
+	if r, ok := resolved.(string); ok && r != "" && isRelativeExcludedTimeInterval(s) {
+		return convertExcludedTimeIntervalFormatToUnix(r, now)
+	}
+	return convertExcludedTimeIntervalFormatToUnix(s, now)
+}
+
+// resolveSchedulerExcludedTimeIntervals ... records the times the configured excluded_time_intervals resolved to, so that reads can match them up again
+func resolveSchedulerExcludedTimeIntervals(excludedTimeIntervals *schema.Set, etList []client.DateTimeInterval) []map[string]interface{} {
+	l := make([]map[string]interface{}, 0)
+
+	for i, item := range excludedTimeIntervals.List() {
+		var schemaMap = item.(map[string]interface{})
+
+		m := make(map[string]interface{})
+		m["from"] = schemaMap["from"]
+		m["to"] = schemaMap["to"]
+		m["from_resolved"] = convertUnixToExcludedTimeIntervalFormat(etList[i].From)
+		m["to_resolved"] = convertUnixToExcludedTimeIntervalFormat(etList[i].To)
+		l = append(l, m)
+	}
+
+	return l
+}
+
 // flattenSchedulerExcludedTimeIntervalsList ... flattens datetime interval objects to generic interface for state
 func flattenSchedulerExcludedTimeIntervalsList(excludedTimeIntervals *[]client.DateTimeInterval) []map[string]interface{} {
 	l := make([]map[string]interface{}, 0)
@@ -301,6 +570,167 @@ func flattenSchedulerExcludedTimeIntervalsList(excludedTimeIntervals *[]client.D
 	return l
 }
 
+// flattenSchedulerExcludedTimeIntervalsSet ... flattens datetime interval objects for state, keeping the configured timestamps of intervals the API still has
+// This keeps relative expressions & alternative timestamp formats from showing up as a change on every plan
+func flattenSchedulerExcludedTimeIntervalsSet(excludedTimeIntervals *[]client.DateTimeInterval, prior *schema.Set) []map[string]interface{} {
+	l := flattenSchedulerExcludedTimeIntervalsList(excludedTimeIntervals)
+
+	for i, item := range *excludedTimeIntervals {
+		l[i]["from_resolved"] = l[i]["from"]
+		l[i]["to_resolved"] = l[i]["to"]
+
+		if schemaMap := findSchedulerExcludedTimeInterval(item, prior); schemaMap != nil {
+			l[i]["from"] = schemaMap["from"]
+			l[i]["to"] = schemaMap["to"]
+		}
+	}
+
+	return l
+}
+
+// findSchedulerExcludedTimeInterval ... returns the excluded_time_intervals element in state that resolved to the datetime interval, if any
+func findSchedulerExcludedTimeInterval(interval client.DateTimeInterval, prior *schema.Set) map[string]interface{} {
+	for _, p := range prior.List() {
+		var schemaMap = p.(map[string]interface{})
+		if expandExcludedTimeIntervalTimestamp(schemaMap["from"].(string), schemaMap["from_resolved"], time.Time{})/1000 == interval.From/1000 &&
+			expandExcludedTimeIntervalTimestamp(schemaMap["to"].(string), schemaMap["to_resolved"], time.Time{})/1000 == interval.To/1000 {
+			return schemaMap
+		}
+	}
+	return nil
+}
+
+// expandSchedulerRecurringExclusions ... expands the recurring_exclusion blocks into the windows that have not ended yet & start within their horizon
+// Occurrences keep the wall clock time of their start in the scheduler timezone, if set
+func expandSchedulerRecurringExclusions(recurringExclusions []interface{}, timezone string, now time.Time) ([]client.DateTimeInterval, error) {
+	windows := make([]client.DateTimeInterval, 0)
+
+	for _, item := range recurringExclusions {
+		var schemaMap = item.(map[string]interface{})
+
+		rule, err := parseRecurrenceRule(schemaMap["rrule"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("[Dotcom-Monitor] Invalid recurring_exclusion rrule: %s", err)
+		}
+		start, err := parseExcludedTimeIntervalTimestamp(schemaMap["start"].(string), now)
+		if err != nil {
+			return nil, fmt.Errorf("[Dotcom-Monitor] Invalid recurring_exclusion start: %s", err)
+		}
+		duration, err := parseRelativeDuration(schemaMap["duration"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("[Dotcom-Monitor] Invalid recurring_exclusion duration: %s", err)
+		}
+		horizon, err := parseRelativeDuration(schemaMap["horizon"].(string))
+		if err != nil {
+			return nil, fmt.Errorf("[Dotcom-Monitor] Invalid recurring_exclusion horizon: %s", err)
+		}
+		if timezone != "" {
+			loc, err := time.LoadLocation(timezone)
+			if err != nil {
+				return nil, fmt.Errorf("[Dotcom-Monitor] Unable to load timezone %q: %s", timezone, err)
+			}
+			start = start.In(loc)
+		}
+
+		// start one duration back so that windows which are in progress are kept
+		for _, occurrence := range rule.occurrences(start, now.Add(-duration), now.Add(horizon)) {
+			end := occurrence.Add(duration)
+			if !end.After(now) {
+				continue
+			}
+			windows = append(windows, client.DateTimeInterval{
+				From: occurrence.Unix() * 1000, // API time is in milliseconds
+				To:   end.Unix() * 1000,
+			})
+		}
+	}
+
+	sort.Slice(windows, func(i, j int) bool {
+		if windows[i].From != windows[j].From {
+			return windows[i].From < windows[j].From
+		}
+		return windows[i].To < windows[j].To
+	})
+
+	return windows, nil
+}
+
+// getSchedulerRecurringExclusionWindows ... returns the planned windows of the recurring exclusions, expanding them now if they were not known at plan time
+func getSchedulerRecurringExclusionWindows(d *schema.ResourceData) ([]client.DateTimeInterval, error) {
+	recurringExclusions := d.Get("recurring_exclusion").([]interface{})
+	if len(recurringExclusions) == 0 {
+		return []client.DateTimeInterval{}, nil
+	}
+
+	if planned := d.Get("recurring_exclusion_windows").([]interface{}); len(planned) > 0 {
+		return expandSchedulerDateTimeIntervalsList(planned), nil
+	}
+
+	return expandSchedulerRecurringExclusions(recurringExclusions, d.Get("timezone").(string), time.Now())
+}
+
+// splitSchedulerRecurringExclusionWindows ... splits the API datetime intervals into the one-off excluded time intervals & the windows of the recurring exclusions
+// An interval counts as a window if it was one before or is one now, unless it also matches a configured excluded time interval
+func splitSchedulerRecurringExclusionWindows(d *schema.ResourceData, intervals []client.DateTimeInterval) ([]client.DateTimeInterval, []client.DateTimeInterval) {
+	excludedTimeIntervals := make([]client.DateTimeInterval, 0)
+	windows := make([]client.DateTimeInterval, 0)
+
+	known := expandSchedulerDateTimeIntervalsList(d.Get("recurring_exclusion_windows").([]interface{}))
+	if expected, err := expandSchedulerRecurringExclusions(d.Get("recurring_exclusion").([]interface{}), d.Get("timezone").(string), time.Now()); err == nil {
+		known = append(known, expected...)
+	}
+	prior := d.Get("excluded_time_intervals").(*schema.Set)
+
+	for _, item := range intervals {
+		if findSchedulerExcludedTimeInterval(item, prior) == nil && schedulerDateTimeIntervalInList(item, known) {
+			windows = append(windows, item)
+			continue
+		}
+		excludedTimeIntervals = append(excludedTimeIntervals, item)
+	}
+
+	return excludedTimeIntervals, windows
+}
+
+// expandSchedulerDateTimeIntervalsList ... constructs a list of dotcommonitor.DateTimeInterval structs from a list of from/to timestamps in state
+func expandSchedulerDateTimeIntervalsList(dateTimeIntervals []interface{}) []client.DateTimeInterval {
+	dtList := make([]client.DateTimeInterval, 0)
+
+	for _, item := range dateTimeIntervals {
+		var schemaMap = item.(map[string]interface{})
+
+		dtList = append(dtList, client.DateTimeInterval{
+			From: convertExcludedTimeIntervalFormatToUnix(schemaMap["from"].(string), time.Time{}),
+			To:   convertExcludedTimeIntervalFormatToUnix(schemaMap["to"].(string), time.Time{}),
+		})
+	}
+
+	return dtList
+}
+
+// schedulerDateTimeIntervalInList ... checks if the datetime interval is in the list, to the second
+func schedulerDateTimeIntervalInList(interval client.DateTimeInterval, intervals []client.DateTimeInterval) bool {
+	for _, item := range intervals {
+		if item.From/1000 == interval.From/1000 && item.To/1000 == interval.To/1000 {
+			return true
+		}
+	}
+	return false
+}
+
+// schedulerDateTimeIntervalsEqual ... checks if two sorted lists of datetime intervals are the same, to the second
+func schedulerDateTimeIntervalsEqual(a, b []client.DateTimeInterval) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].From/1000 != b[i].From/1000 || a[i].To/1000 != b[i].To/1000 {
+			return false
+		}
+	}
+	return true
+}
+
 // convertDurationStringToMinutes ... converts time duration string into minutes
 func convertDurationStringToMinutes(s string) int {
 	d, _ := time.ParseDuration(s)
@@ -315,14 +745,19 @@ func convertMinutesToDurationString(i int) string {
 }
 
 // convertUnixToExcludedTimeIntervalFormat ... converts Unix epoch time to time format string
+// Whole minutes keep the original "YYYY-MM-DDThh:mmZ" format, anything more precise is written as RFC 3339
 func convertUnixToExcludedTimeIntervalFormat(i int64) string {
 	t := time.Unix(i/1000, 0).UTC() // API time is in milliseconds
+	if t.Second() != 0 {
+		return t.Format(time.RFC3339)
+	}
 	tf := t.Format(schedulerExcludedTimeIntervalLayout)
 	return tf
 }
 
-// convertExcludedTimeIntervalFormatToUnix ... converts time format string to Unix epoch time
-func convertExcludedTimeIntervalFormatToUnix(s string) int64 {
-	u, _ := time.Parse(schedulerExcludedTimeIntervalLayout, s)
+// convertExcludedTimeIntervalFormatToUnix ... converts an absolute or relative timestamp to Unix epoch time
+func convertExcludedTimeIntervalFormatToUnix(s string, now time.Time) int64 {
+	// the input is already checked by validateExcludedTimeIntervalTimestamp
+	u, _ := parseExcludedTimeIntervalTimestamp(s, now)
 	return u.Unix() * 1000 // API time is in milliseconds
 }
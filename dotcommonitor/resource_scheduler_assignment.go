@@ -0,0 +1,263 @@
+package dotcommonitor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+func resourceSchedulerAssignment() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSchedulerAssignmentCreate,
+		Read:   resourceSchedulerAssignmentRead,
+		Update: resourceSchedulerAssignmentUpdate,
+		Delete: resourceSchedulerAssignmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSchedulerAssignmentImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"scheduler_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"device_ids": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeInt},
+				AtLeastOneOf: []string{"device_ids", "group_ids"},
+			},
+			"group_ids": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeInt},
+			},
+			"force_reassign": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceSchedulerAssignmentCreate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	api := meta.(*client.APIClient)
+	schedulerID := d.Get("scheduler_id").(int)
+
+	deviceIDs := expandIntSet(d.Get("device_ids").(*schema.Set))
+	groupIDs := expandIntSet(d.Get("group_ids").(*schema.Set))
+	log.Printf("[Dotcom-Monitor] Scheduler assignment create configuration: scheduler ID %v, devices %v, groups %v", schedulerID, deviceIDs, groupIDs)
+
+	err := applySchedulerAssignment(api, schedulerID, []int{}, deviceIDs, []int{}, groupIDs, d.Get("force_reassign").(bool))
+
+	if err != nil {
+		mutex.Unlock()
+		return err
+	}
+
+	log.Printf("[Dotcom-Monitor] Scheduler assignment successfully created - scheduler ID: %v", schedulerID)
+
+	// Set ID
+	d.SetId(fmt.Sprint(schedulerID))
+
+	mutex.Unlock()
+	return resourceSchedulerAssignmentRead(d, meta)
+}
+
+func resourceSchedulerAssignmentRead(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	// Pull scheduler ID from state
+	schedulerID, _ := strconv.Atoi(d.Id())
+
+	scheduler := &client.Scheduler{}
+	scheduler.ID = schedulerID
+
+	api := meta.(*client.APIClient)
+	err := api.GetScheduler(scheduler)
+
+	if err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get scheduler: %s", err)
+	}
+
+	// Check if scheduler exists before trying to read it
+	if !(scheduler.ID > 0) {
+		log.Printf("[Dotcom-Monitor] [WARNING] Scheduler does not exist, removing assignment ID %v from state", schedulerID)
+		d.SetId("")
+		return nil
+	}
+
+	// only the devices & groups in state are managed by this resource, the importer seeds them
+	deviceIDs := expandIntSet(d.Get("device_ids").(*schema.Set))
+	groupIDs := expandIntSet(d.Get("group_ids").(*schema.Set))
+
+	// set state to detect drift
+	d.Set("scheduler_id", scheduler.ID)
+	d.Set("device_ids", intListIntersection(deviceIDs, scheduler.AssignedTo.Devices))
+	d.Set("group_ids", intListIntersection(groupIDs, scheduler.AssignedTo.Groups))
+
+	return nil
+}
+
+// resourceSchedulerAssignmentImport ... adopts every device & group currently assigned to the scheduler
+func resourceSchedulerAssignmentImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	schedulerID, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("[Dotcom-Monitor] Invalid scheduler assignment import ID %q, must be <scheduler_id>", d.Id())
+	}
+
+	scheduler := &client.Scheduler{}
+	scheduler.ID = schedulerID
+
+	api := meta.(*client.APIClient)
+	if err := api.GetScheduler(scheduler); err != nil {
+		return nil, fmt.Errorf("[Dotcom-Monitor] Failed to get scheduler: %s", err)
+	}
+	if !(scheduler.ID > 0) {
+		return nil, fmt.Errorf("[Dotcom-Monitor] Scheduler ID %v does not exist", schedulerID)
+	}
+
+	d.Set("device_ids", scheduler.AssignedTo.Devices)
+	d.Set("group_ids", scheduler.AssignedTo.Groups)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceSchedulerAssignmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+
+	api := meta.(*client.APIClient)
+	schedulerID, _ := strconv.Atoi(d.Id())
+
+	oldDevices, newDevices := d.GetChange("device_ids")
+	oldGroups, newGroups := d.GetChange("group_ids")
+
+	log.Printf("[Dotcom-Monitor] Attempting to update scheduler assignment ID: %v", schedulerID)
+
+	err := applySchedulerAssignment(api, schedulerID,
+		expandIntSet(oldDevices.(*schema.Set)), expandIntSet(newDevices.(*schema.Set)),
+		expandIntSet(oldGroups.(*schema.Set)), expandIntSet(newGroups.(*schema.Set)),
+		d.Get("force_reassign").(bool))
+
+	if err != nil {
+		mutex.Unlock()
+		return err
+	}
+
+	log.Printf("[Dotcom-Monitor] Scheduler assignment ID: %v successfully updated", schedulerID)
+
+	mutex.Unlock()
+	return resourceSchedulerAssignmentRead(d, meta)
+}
+
+func resourceSchedulerAssignmentDelete(d *schema.ResourceData, meta interface{}) error {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	api := meta.(*client.APIClient)
+	schedulerID, _ := strconv.Atoi(d.Id())
+
+	err := applySchedulerAssignment(api, schedulerID,
+		expandIntSet(d.Get("device_ids").(*schema.Set)), []int{},
+		expandIntSet(d.Get("group_ids").(*schema.Set)), []int{},
+		true)
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+//////////////////////////////
+// Scheduler assignment helpers
+//////////////////////////////
+
+// applySchedulerAssignment ... moves the scheduler assignments from the old devices & groups to the new ones, leaving any other assignments of the scheduler alone
+//
+// Devices & groups that are being added must not be assigned to another scheduler,
+// e.g. through the scheduler_id of a dotcommonitor_device or dotcommonitor_group
+// resource, unless forceReassign is set.
+func applySchedulerAssignment(api *client.APIClient, schedulerID int, oldDevices, newDevices, oldGroups, newGroups []int, forceReassign bool) error {
+	addedDevices := intListDifference(newDevices, oldDevices)
+	addedGroups := intListDifference(newGroups, oldGroups)
+
+	if !forceReassign {
+		if err := detectSchedulerAssignmentConflicts(api, schedulerID, addedDevices, addedGroups); err != nil {
+			return err
+		}
+	}
+
+	// read the scheduler fresh so that assignments made elsewhere are kept
+	scheduler := &client.Scheduler{ID: schedulerID}
+	if err := api.GetScheduler(scheduler); err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Failed to get scheduler: %s", err)
+	}
+	if !(scheduler.ID > 0) {
+		if len(newDevices) == 0 && len(newGroups) == 0 {
+			return nil // nothing left to unassign
+		}
+		return fmt.Errorf("[Dotcom-Monitor] Scheduler ID %v does not exist", schedulerID)
+	}
+
+	scheduler.AssignedTo.Devices = intListUnion(intListDifference(scheduler.AssignedTo.Devices, intListDifference(oldDevices, newDevices)), addedDevices)
+	scheduler.AssignedTo.Groups = intListUnion(intListDifference(scheduler.AssignedTo.Groups, intListDifference(oldGroups, newGroups)), addedGroups)
+	log.Printf("[Dotcom-Monitor] Scheduler ID %v assignments: devices %v, groups %v", schedulerID, scheduler.AssignedTo.Devices, scheduler.AssignedTo.Groups)
+
+	if err := api.UpdateScheduler(scheduler); err != nil {
+		return fmt.Errorf("[Dotcom-Monitor] Failed to update scheduler assignments: %s", err)
+	}
+
+	return nil
+}
+
+// detectSchedulerAssignmentConflicts ... returns an error listing the devices & groups that are already assigned to another scheduler
+func detectSchedulerAssignmentConflicts(api *client.APIClient, schedulerID int, deviceIDs, groupIDs []int) error {
+	var conflicts []string
+
+	for _, deviceID := range deviceIDs {
+		device := &client.Device{ID: deviceID}
+		if err := api.GetDevice(device); err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to get device: %s", err)
+		}
+		if !(device.ID > 0) {
+			return fmt.Errorf("[Dotcom-Monitor] Device ID %v does not exist", deviceID)
+		}
+		if device.SchedulerID != 0 && device.SchedulerID != schedulerID {
+			conflicts = append(conflicts, fmt.Sprintf("device ID %v is assigned to scheduler ID %v", deviceID, device.SchedulerID))
+		}
+	}
+
+	for _, groupID := range groupIDs {
+		group := &client.Group{ID: groupID}
+		if err := api.GetGroup(group); err != nil {
+			return fmt.Errorf("[Dotcom-Monitor] Failed to get group: %s", err)
+		}
+		if !(group.ID > 0) {
+			return fmt.Errorf("[Dotcom-Monitor] Group ID %v does not exist", groupID)
+		}
+		if group.SchedulerID != 0 && group.SchedulerID != schedulerID {
+			conflicts = append(conflicts, fmt.Sprintf("group ID %v is assigned to scheduler ID %v", groupID, group.SchedulerID))
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("[Dotcom-Monitor] Scheduler assignment conflicts: %v. Remove the scheduler_id argument of those devices & groups, or set force_reassign to move them to scheduler ID %v", conflicts, schedulerID)
+	}
+
+	return nil
+}
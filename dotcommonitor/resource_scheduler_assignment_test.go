@@ -0,0 +1,30 @@
+package dotcommonitor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+func TestApplySchedulerAssignmentUnassignsLastDevice(t *testing.T) {
+	fake := newFakeAPI(t, map[string]interface{}{
+		"scheduler/3": client.Scheduler{ID: 3, Name: "example", AssignedTo: client.AssignedTo{Devices: []int{5}, Groups: []int{8}}},
+	})
+
+	if err := applySchedulerAssignment(fake.client(t), 3, []int{5}, nil, []int{8}, nil, false); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	update := fake.lastUpdate("scheduler/3")
+	for _, want := range []string{`"Devices":[]`, `"Notification_Groups":[]`} {
+		if !strings.Contains(update, want) {
+			t.Errorf("update = %s, want %s", update, want)
+		}
+	}
+	var scheduler client.Scheduler
+	fake.get(t, "scheduler/3", &scheduler)
+	if len(scheduler.AssignedTo.Devices) != 0 || len(scheduler.AssignedTo.Groups) != 0 {
+		t.Errorf("assigned to = %+v, want nothing", scheduler.AssignedTo)
+	}
+}
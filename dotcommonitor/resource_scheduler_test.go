@@ -0,0 +1,43 @@
+package dotcommonitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceSchedulerCustomizeDiffTimezoneOffset(t *testing.T) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":     "example",
+		"timezone": "Asia/Tokyo",
+	})
+	state := func(offset string) *terraform.InstanceState {
+		return &terraform.InstanceState{
+			ID: "1",
+			Attributes: map[string]string{
+				"id":                      "1",
+				"name":                    "example",
+				"timezone":                "Asia/Tokyo",
+				"timezone_offset_minutes": offset,
+				"ignore_plan_warnings":    "false",
+			},
+		}
+	}
+
+	diff, err := resourceScheduler().Diff(context.Background(), state("0"), config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff == nil || diff.Attributes["timezone_offset_minutes"] == nil || diff.Attributes["timezone_offset_minutes"].New != "540" {
+		t.Fatalf("diff = %v, want timezone_offset_minutes to change to 540", diff)
+	}
+
+	diff, err = resourceScheduler().Diff(context.Background(), state("540"), config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff != nil && diff.Attributes["timezone_offset_minutes"] != nil {
+		t.Fatalf("diff = %v, want no change to timezone_offset_minutes", diff)
+	}
+}
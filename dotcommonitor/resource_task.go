@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -364,13 +365,13 @@ func resourceTaskRead(d *schema.ResourceData, meta interface{}) error {
 	d.Set("ssl_expiration_reminder_in_days", task.SSLExpirationReminderInDays)
 	d.Set("ssl_client_certificate", task.SSLClientCertificate)
 	if task.GetParams != nil {
-		d.Set("get_params", task.GetParams)
+		d.Set("get_params", flattenTaskParamList(&task.GetParams))
 	}
 	if task.PostParams != nil {
-		d.Set("post_params", task.PostParams)
+		d.Set("post_params", flattenTaskParamList(&task.PostParams))
 	}
 	if task.HeaderParams != nil {
-		d.Set("header_params", task.HeaderParams)
+		d.Set("header_params", flattenTaskParamList(&task.HeaderParams))
 	}
 	d.Set("prepare_script", task.PrepareScript)
 	d.Set("dns_resolve_mode", task.DNSResolveMode)
@@ -490,6 +491,21 @@ func expandSetToTaskParamList(sets *schema.Set) []client.TaskParam {
 	return taskParamList
 }
 
+// flattenTaskParamList ... flattens task param objects to generic interface for state
+func flattenTaskParamList(params *[]client.TaskParam) []map[string]interface{} {
+	l := make([]map[string]interface{}, 0)
+
+	for _, item := range *params {
+		m := make(map[string]interface{})
+		m["name"] = item.Name
+		m["value"] = item.Value
+
+		l = append(l, m)
+	}
+
+	return l
+}
+
 // expandCustomDnsHostsToString ... returns a string required for the syntax of "CustomDNSHosts"
 //  Syntax:  <host>=<ip>;
 func expandCustomDnsHostsToString(hosts []interface{}) string {
@@ -510,3 +526,24 @@ func expandCustomDnsHostsToString(hosts []interface{}) string {
 
 	return resultString
 }
+
+// flattenCustomDnsHostsString ... flattens a "CustomDNSHosts" string to generic interface for state
+//  Syntax:  <host>=<ip>;
+func flattenCustomDnsHostsString(hosts string) []map[string]interface{} {
+	l := make([]map[string]interface{}, 0)
+
+	for _, item := range strings.Split(hosts, ";") {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		m := make(map[string]interface{})
+		m["host"] = parts[0]
+		m["ip_address"] = parts[1]
+
+		l = append(l, m)
+	}
+
+	return l
+}
@@ -0,0 +1,523 @@
+package dotcommonitor
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // embed the IANA database so scheduler timezones resolve on hosts without one
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+// schedulerReferenceTimezone ... the zone the API evaluates weekly intervals in
+// The scheduler reference (https://www.dotcom-monitor.com/wiki/knowledge-base/edit-scheduler/) gives
+// From_Min & To_Min as minutes of the day without naming a zone. Its Date_Time_Intervals are UTC based
+// Unix times, and weekly intervals have always been passed as UTC by this provider, so UTC is assumed.
+const schedulerReferenceTimezone = "UTC"
+
+const (
+	minutesPerDay  = 24 * 60
+	minutesPerWeek = 7 * minutesPerDay
+)
+
+// schedulerWeekDays ... API day strings, indexed the same way as time.Weekday
+var schedulerWeekDays = []string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"}
+
+// weekSegment ... a weekly interval in minutes since the start of the week (Sunday 00:00)
+// Segments are half-open, [Start, End), and the API's end of day "To_Min" of 1439 (23h59m) is treated as midnight
+type weekSegment struct {
+	Start   int
+	End     int
+	Enabled bool
+}
+
+// weeklyIntervalEndMinute ... returns the end of a weekly interval within its day, reading 23h59m as midnight
+func weeklyIntervalEndMinute(toMinute int) int {
+	if toMinute >= minutesPerDay-1 {
+		return minutesPerDay
+	}
+	return toMinute
+}
+
+// schedulerDayIndex ... returns the time.Weekday index of an API day string, or -1 if it is not valid
+func schedulerDayIndex(day string) int {
+	for i, item := range schedulerWeekDays {
+		if item == day {
+			return i
+		}
+	}
+	return -1
+}
+
+// schedulerTimezoneOffset ... returns the offset in minutes of the timezone from the API reference zone at the given time
+func schedulerTimezoneOffset(timezone string, at time.Time) (int, error) {
+	if timezone == "" {
+		return 0, nil
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return 0, fmt.Errorf("unable to load timezone %q: %s", timezone, err)
+	}
+	ref, err := time.LoadLocation(schedulerReferenceTimezone)
+	if err != nil {
+		return 0, fmt.Errorf("unable to load timezone %q: %s", schedulerReferenceTimezone, err)
+	}
+
+	_, offset := at.In(loc).Zone()
+	_, refOffset := at.In(ref).Zone()
+	return (offset - refOffset) / 60, nil
+}
+
+// weeklyIntervalsToSegments ... breaks weekly intervals down into one segment per day
+func weeklyIntervalsToSegments(weeklyIntervals []client.WeeklyInterval) []weekSegment {
+	var segments []weekSegment
+
+	for _, item := range weeklyIntervals {
+		for _, day := range item.Days {
+			index := schedulerDayIndex(day)
+			if index < 0 {
+				continue
+			}
+			segments = append(segments, weekSegment{
+				Start:   index*minutesPerDay + item.FromMinute,
+				End:     index*minutesPerDay + weeklyIntervalEndMinute(item.ToMinute),
+				Enabled: item.Enabled,
+			})
+		}
+	}
+
+	return segments
+}
+
+// shiftWeekSegments ... moves segments by the given number of minutes, wrapping around the end of the week
+// Segments that end up crossing midnight are split into one segment per day
+func shiftWeekSegments(segments []weekSegment, minutes int) []weekSegment {
+	var shifted []weekSegment
+
+	for _, item := range segments {
+		start := ((item.Start+minutes)%minutesPerWeek + minutesPerWeek) % minutesPerWeek
+		end := start + (item.End - item.Start)
+
+		for start < end {
+			pieceEnd := (start/minutesPerDay + 1) * minutesPerDay
+			if pieceEnd > end {
+				pieceEnd = end
+			}
+			base := start - start%minutesPerWeek
+			shifted = append(shifted, weekSegment{
+				Start:   start - base,
+				End:     pieceEnd - base,
+				Enabled: item.Enabled,
+			})
+			start = pieceEnd
+		}
+	}
+
+	return shifted
+}
+
+// mergeWeekSegments ... joins segments with the same enabled flag that touch or overlap, including across midnight
+func mergeWeekSegments(segments []weekSegment) []weekSegment {
+	if len(segments) == 0 {
+		return segments
+	}
+
+	sorted := make([]weekSegment, len(segments))
+	copy(sorted, segments)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Enabled != sorted[j].Enabled {
+			return sorted[i].Enabled
+		}
+		return sorted[i].Start < sorted[j].Start
+	})
+
+	var merged []weekSegment
+	for _, item := range sorted {
+		last := len(merged) - 1
+		if last >= 0 && merged[last].Enabled == item.Enabled && item.Start <= merged[last].End {
+			if item.End > merged[last].End {
+				merged[last].End = item.End
+			}
+			continue
+		}
+		merged = append(merged, item)
+	}
+
+	return merged
+}
+
+// segmentsToWeeklyIntervals ... splits segments at midnight & groups the pieces sharing the same times back into weekly intervals
+// Pieces too short to be represented by the API (the last minute of a day) are dropped
+func segmentsToWeeklyIntervals(segments []weekSegment) []client.WeeklyInterval {
+	type intervalKey struct {
+		from    int
+		to      int
+		enabled bool
+	}
+	days := make(map[intervalKey][]int)
+	var keys []intervalKey
+
+	for _, item := range shiftWeekSegments(segments, 0) {
+		key := intervalKey{
+			from:    item.Start % minutesPerDay,
+			to:      item.End - (item.Start/minutesPerDay)*minutesPerDay,
+			enabled: item.Enabled,
+		}
+		if key.to == minutesPerDay {
+			key.to = minutesPerDay - 1 // midnight is sent to the API as 23h59m
+		}
+		if key.from >= key.to {
+			continue
+		}
+		if _, ok := days[key]; !ok {
+			keys = append(keys, key)
+		}
+		days[key] = append(days[key], item.Start/minutesPerDay)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].from != keys[j].from {
+			return keys[i].from < keys[j].from
+		}
+		if keys[i].to != keys[j].to {
+			return keys[i].to < keys[j].to
+		}
+		return keys[i].enabled
+	})
+
+	wiList := make([]client.WeeklyInterval, 0, len(keys))
+	for _, key := range keys {
+		dayIndexes := days[key]
+		sort.Ints(dayIndexes)
+
+		var dayStrings []string
+		for _, index := range dayIndexes {
+			if len(dayStrings) == 0 || dayStrings[len(dayStrings)-1] != schedulerWeekDays[index] {
+				dayStrings = append(dayStrings, schedulerWeekDays[index])
+			}
+		}
+
+		wiList = append(wiList, client.WeeklyInterval{
+			Days:       dayStrings,
+			FromMinute: key.from,
+			ToMinute:   key.to,
+			Enabled:    key.enabled,
+		})
+	}
+
+	return wiList
+}
+
+// convertWeeklyIntervalsToReference ... converts weekly intervals configured in the timezone to the API reference zone
+func convertWeeklyIntervalsToReference(weeklyIntervals []client.WeeklyInterval, timezone string, at time.Time) ([]client.WeeklyInterval, error) {
+	if timezone == "" {
+		return weeklyIntervals, nil
+	}
+
+	offset, err := schedulerTimezoneOffset(timezone, at)
+	if err != nil {
+		return nil, err
+	}
+
+	// local = reference + offset, so going to the reference zone subtracts the offset
+	segments := shiftWeekSegments(weeklyIntervalsToSegments(weeklyIntervals), -offset)
+	return segmentsToWeeklyIntervals(segments), nil
+}
+
+// convertWeeklyIntervalsFromReference ... converts weekly intervals read from the API to the timezone
+// Pieces that were split at midnight on the way in are merged back together first
+func convertWeeklyIntervalsFromReference(weeklyIntervals []client.WeeklyInterval, timezone string, at time.Time) ([]client.WeeklyInterval, error) {
+	if timezone == "" {
+		return weeklyIntervals, nil
+	}
+
+	offset, err := schedulerTimezoneOffset(timezone, at)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := mergeWeekSegments(shiftWeekSegments(weeklyIntervalsToSegments(weeklyIntervals), offset))
+	return segmentsToWeeklyIntervals(joinWeekWrap(segments)), nil
+}
+
+// joinWeekWrap ... joins a segment ending Saturday midnight with one starting Sunday 00:00 so that it can be split at midnight like any other
+func joinWeekWrap(segments []weekSegment) []weekSegment {
+	var result []weekSegment
+	joined := make(map[int]bool)
+
+	for i, last := range segments {
+		if last.End != minutesPerWeek {
+			continue
+		}
+		for j, first := range segments {
+			if i != j && !joined[j] && !joined[i] && first.Start == 0 && first.Enabled == last.Enabled {
+				result = append(result, weekSegment{Start: last.Start, End: minutesPerWeek + first.End, Enabled: last.Enabled})
+				joined[i] = true
+				joined[j] = true
+			}
+		}
+	}
+	for i, item := range segments {
+		if !joined[i] {
+			result = append(result, item)
+		}
+	}
+
+	return result
+}
+
+// weeklyIntervalMinutes ... marks the minutes of the week enabled & disabled by weekly intervals
+func weeklyIntervalMinutes(weeklyIntervals []client.WeeklyInterval) (enabled []bool, disabled []bool) {
+	enabled = make([]bool, minutesPerWeek)
+	disabled = make([]bool, minutesPerWeek)
+
+	for _, item := range weeklyIntervalsToSegments(weeklyIntervals) {
+		for m := item.Start; m < item.End && m < minutesPerWeek; m++ {
+			if item.Enabled {
+				enabled[m] = true
+			} else {
+				disabled[m] = true
+			}
+		}
+	}
+
+	return enabled, disabled
+}
+
+// weeklyIntervalsEquivalent ... checks if two lists of weekly intervals enable & disable the same minutes of the week,
+// however they are split or grouped by day
+// The last minute of each day is ignored, the API cannot tell an interval ending at 23h59m from one ending at midnight
+func weeklyIntervalsEquivalent(a, b []client.WeeklyInterval) bool {
+	enabledA, disabledA := weeklyIntervalMinutes(a)
+	enabledB, disabledB := weeklyIntervalMinutes(b)
+
+	for m := 0; m < minutesPerWeek; m++ {
+		if m%minutesPerDay == minutesPerDay-1 {
+			continue
+		}
+		if enabledA[m] != enabledB[m] || disabledA[m] != disabledB[m] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// schedulerExcludedTimeIntervalLayouts ... absolute timestamp layouts accepted for excluded time intervals, in the order they are tried
+// RFC 3339 covers the output of Terraform's timestamp() & timeadd() functions, fractional seconds included
+var schedulerExcludedTimeIntervalLayouts = []string{
+	schedulerExcludedTimeIntervalLayout,
+	time.RFC3339,
+	"2006-01-02T15:04Z07:00",
+}
+
+// schedulerRelativeTimeRegexp ... matches relative expressions such as "now", "now+2h" or "now - 1d12h"
+var schedulerRelativeTimeRegexp = regexp.MustCompile(`^now(?:\s*([+-])\s*(\S+))?$`)
+
+// isRelativeExcludedTimeInterval ... checks if the excluded time interval timestamp is relative to the current time
+func isRelativeExcludedTimeInterval(s string) bool {
+	return schedulerRelativeTimeRegexp.MatchString(strings.TrimSpace(strings.ToLower(s)))
+}
+
+// parseExcludedTimeIntervalTimestamp ... parses an absolute or relative excluded time interval timestamp
+// Relative expressions are resolved against now
+func parseExcludedTimeIntervalTimestamp(s string, now time.Time) (time.Time, error) {
+	v := strings.TrimSpace(s)
+
+	if m := schedulerRelativeTimeRegexp.FindStringSubmatch(strings.ToLower(v)); m != nil {
+		if m[1] == "" {
+			return now.UTC(), nil
+		}
+		offset, err := parseRelativeDuration(m[2])
+		if err != nil {
+			return time.Time{}, err
+		}
+		if m[1] == "-" {
+			offset = -offset
+		}
+		return now.Add(offset).UTC(), nil
+	}
+
+	for _, layout := range schedulerExcludedTimeIntervalLayouts {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t.UTC(), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%q is not an RFC 3339 timestamp (for example, \"2014-06-01T00:00:00Z\" or \"2014-06-01T02:00:00+02:00\") or a relative expression (for example, \"now+2h\")", s)
+}
+
+// parseRelativeDuration ... parses a Go duration string that may also start with a number of days, e.g. "1d12h"
+func parseRelativeDuration(s string) (time.Duration, error) {
+	var days time.Duration
+	if i := strings.Index(s, "d"); i > 0 {
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, fmt.Errorf("%q is not a valid duration", s)
+		}
+		days = time.Duration(n) * 24 * time.Hour
+		s = s[i+1:]
+		if s == "" {
+			return days, nil
+		}
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid duration", s)
+	}
+	return days + d, nil
+}
+
+// excludedTimeIntervalTimestampsEqual ... checks if two absolute excluded time interval timestamps are the same point in time
+func excludedTimeIntervalTimestampsEqual(a, b string) bool {
+	if isRelativeExcludedTimeInterval(a) || isRelativeExcludedTimeInterval(b) {
+		return a == b
+	}
+
+	ta, errA := parseExcludedTimeIntervalTimestamp(a, time.Time{})
+	tb, errB := parseExcludedTimeIntervalTimestamp(b, time.Time{})
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return ta.Unix() == tb.Unix() // the API only keeps second precision
+}
+
+// suppressEquivalentExcludedTimeInterval ... suppresses the diff of timestamps that are the same point in time written differently
+func suppressEquivalentExcludedTimeInterval(k, old, new string, d *schema.ResourceData) bool {
+	return excludedTimeIntervalTimestampsEqual(old, new)
+}
+
+// hashSchedulerExcludedTimeInterval ... hashes an excluded time interval by the points in time it covers rather than how they are written
+// Relative expressions are hashed as written, since they resolve to a different time on every plan
+func hashSchedulerExcludedTimeInterval(v interface{}) int {
+	m := v.(map[string]interface{})
+
+	key := func(s string) string {
+		if isRelativeExcludedTimeInterval(s) {
+			return strings.ToLower(strings.Join(strings.Fields(s), ""))
+		}
+		if t, err := parseExcludedTimeIntervalTimestamp(s, time.Time{}); err == nil {
+			return fmt.Sprint(t.Unix())
+		}
+		return s
+	}
+
+	return schema.HashString(fmt.Sprintf("%s-%s", key(m["from"].(string)), key(m["to"].(string))))
+}
+
+// describeWeekMinute ... describes a minute of the week for diagnostics, e.g. "Mo 9h30m"
+func describeWeekMinute(minute int) string {
+	return fmt.Sprintf("%s %s", schedulerWeekDays[(minute/minutesPerDay)%7], convertMinutesToDurationString(minute%minutesPerDay))
+}
+
+// describeWeeklyInterval ... describes a weekly interval for diagnostics, e.g. "[Mo Tu] 9h0m-17h0m (enabled)"
+func describeWeeklyInterval(item client.WeeklyInterval) string {
+	state := "disabled"
+	if item.Enabled {
+		state = "enabled"
+	}
+	return fmt.Sprintf("%v %s-%s (%s)", item.Days, convertMinutesToDurationString(item.FromMinute), convertMinutesToDurationString(item.ToMinute), state)
+}
+
+// analyzeWeeklyIntervals ... checks weekly intervals against each other
+//
+// Returns errors for intervals that can never apply, i.e. invalid days or a from that is
+// not before its to. Returns warnings for intervals that overlap: an enabled & a disabled
+// interval covering the same time, the same day listed twice with the same times, or
+// intervals with the same enabled flag that overlap.
+func analyzeWeeklyIntervals(weeklyIntervals []client.WeeklyInterval) (errs []string, warnings []string) {
+	type daySegment struct {
+		weekSegment
+		interval int
+	}
+	var segments []daySegment
+
+	for i, item := range weeklyIntervals {
+		if invalidDays := detectInvalidSchedulerWeeklyIntervalDays(item.Days); len(invalidDays) > 0 {
+			errs = append(errs, fmt.Sprintf("weekly interval %s has invalid days %v", describeWeeklyInterval(item), invalidDays))
+			continue
+		}
+		if item.FromMinute >= weeklyIntervalEndMinute(item.ToMinute) {
+			errs = append(errs, fmt.Sprintf("weekly interval %s starts at or after it ends, intervals cannot cross midnight so split it into one interval per day", describeWeeklyInterval(item)))
+			continue
+		}
+		for _, segment := range weeklyIntervalsToSegments([]client.WeeklyInterval{item}) {
+			segments = append(segments, daySegment{weekSegment: segment, interval: i})
+		}
+	}
+
+	for i := range segments {
+		for j := i + 1; j < len(segments); j++ {
+			a, b := segments[i], segments[j]
+			if a.interval == b.interval || a.Start >= b.End || b.Start >= a.End {
+				continue
+			}
+
+			overlapStart := a.Start
+			if b.Start > overlapStart {
+				overlapStart = b.Start
+			}
+			where := describeWeekMinute(overlapStart)
+			first, second := describeWeeklyInterval(weeklyIntervals[a.interval]), describeWeeklyInterval(weeklyIntervals[b.interval])
+
+			switch {
+			case a.Enabled != b.Enabled:
+				warnings = append(warnings, fmt.Sprintf("weekly intervals %s and %s contradict each other from %s, weekly_coverage_minutes assumes the disabled interval wins", first, second, where))
+			case a.Start == b.Start && a.End == b.End:
+				warnings = append(warnings, fmt.Sprintf("weekly intervals %s and %s both list %s with the same times", first, second, schedulerWeekDays[a.Start/minutesPerDay]))
+			default:
+				warnings = append(warnings, fmt.Sprintf("weekly intervals %s and %s overlap from %s", first, second, where))
+			}
+		}
+	}
+
+	return errs, warnings
+}
+
+// analyzeExcludedTimeIntervals ... checks excluded time intervals for ranges that are empty or already over
+func analyzeExcludedTimeIntervals(excludedTimeIntervals []client.DateTimeInterval, now time.Time) (errs []string, warnings []string) {
+	for _, item := range excludedTimeIntervals {
+		from := convertUnixToExcludedTimeIntervalFormat(item.From)
+		to := convertUnixToExcludedTimeIntervalFormat(item.To)
+
+		switch {
+		case item.From >= item.To:
+			errs = append(errs, fmt.Sprintf("excluded time interval %s to %s starts at or after it ends", from, to))
+		case item.To/1000 <= now.Unix():
+			warnings = append(warnings, fmt.Sprintf("excluded time interval %s to %s is in the past and has no effect", from, to))
+		}
+	}
+
+	return errs, warnings
+}
+
+// weeklyCoverageMinutes ... returns how many minutes of the week are monitored, i.e. covered by an enabled weekly interval & not by a disabled one
+func weeklyCoverageMinutes(weeklyIntervals []client.WeeklyInterval) int {
+	covered := make([]bool, minutesPerWeek)
+	excluded := make([]bool, minutesPerWeek)
+
+	for _, item := range weeklyIntervalsToSegments(weeklyIntervals) {
+		for m := item.Start; m < item.End && m < minutesPerWeek; m++ {
+			if item.Enabled {
+				covered[m] = true
+			} else {
+				excluded[m] = true
+			}
+		}
+	}
+
+	total := 0
+	for m := range covered {
+		if covered[m] && !excluded[m] {
+			total++
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,174 @@
+package dotcommonitor
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/rymancl/terraform-provider-dotcommonitor/dotcommonitor/client"
+)
+
+var (
+	schedulerWinter = time.Date(2021, 1, 15, 12, 0, 0, 0, time.UTC)
+	schedulerSummer = time.Date(2021, 7, 15, 12, 0, 0, 0, time.UTC)
+)
+
+func TestConvertWeeklyIntervalsRoundTrip(t *testing.T) {
+	cases := []struct {
+		name      string
+		timezone  string
+		at        time.Time
+		local     []client.WeeklyInterval
+		reference []client.WeeklyInterval
+	}{
+		{
+			name:      "ahead of UTC, moves to the previous day",
+			timezone:  "Asia/Tokyo",
+			at:        schedulerWinter,
+			local:     []client.WeeklyInterval{{Days: []string{"Mo"}, FromMinute: 60, ToMinute: 300, Enabled: true}},
+			reference: []client.WeeklyInterval{{Days: []string{"Su"}, FromMinute: 16 * 60, ToMinute: 20 * 60, Enabled: true}},
+		},
+		{
+			name:     "behind UTC, crosses midnight",
+			timezone: "America/New_York",
+			at:       schedulerWinter,
+			local:    []client.WeeklyInterval{{Days: []string{"Mo", "We"}, FromMinute: 20 * 60, ToMinute: 1439, Enabled: true}},
+			reference: []client.WeeklyInterval{
+				{Days: []string{"Tu", "Th"}, FromMinute: 60, ToMinute: 5 * 60, Enabled: true},
+			},
+		},
+		{
+			name:     "behind UTC, splits at midnight",
+			timezone: "America/New_York",
+			at:       schedulerWinter,
+			local:    []client.WeeklyInterval{{Days: []string{"Tu"}, FromMinute: 17 * 60, ToMinute: 22 * 60, Enabled: true}},
+			reference: []client.WeeklyInterval{
+				{Days: []string{"We"}, FromMinute: 0, ToMinute: 3 * 60, Enabled: true},
+				{Days: []string{"Tu"}, FromMinute: 22 * 60, ToMinute: 1439, Enabled: true},
+			},
+		},
+		{
+			name:      "ahead of UTC, wraps to the end of the previous week",
+			timezone:  "Asia/Tokyo",
+			at:        schedulerWinter,
+			local:     []client.WeeklyInterval{{Days: []string{"Su"}, FromMinute: 0, ToMinute: 5 * 60, Enabled: false}},
+			reference: []client.WeeklyInterval{{Days: []string{"Sa"}, FromMinute: 15 * 60, ToMinute: 20 * 60, Enabled: false}},
+		},
+		{
+			name:     "behind UTC, wraps to the start of the next week across midnight",
+			timezone: "America/New_York",
+			at:       schedulerSummer,
+			local:    []client.WeeklyInterval{{Days: []string{"Sa"}, FromMinute: 18 * 60, ToMinute: 22 * 60, Enabled: true}},
+			reference: []client.WeeklyInterval{
+				{Days: []string{"Su"}, FromMinute: 0, ToMinute: 2 * 60, Enabled: true},
+				{Days: []string{"Sa"}, FromMinute: 22 * 60, ToMinute: 1439, Enabled: true},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reference, err := convertWeeklyIntervalsToReference(tc.local, tc.timezone, tc.at)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(reference, tc.reference) {
+				t.Fatalf("to reference = %v, want %v", reference, tc.reference)
+			}
+
+			local, err := convertWeeklyIntervalsFromReference(reference, tc.timezone, tc.at)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(local, tc.local) {
+				t.Fatalf("back from reference = %v, want %v", local, tc.local)
+			}
+			if !weeklyIntervalsEquivalent(reference, tc.reference) {
+				t.Fatalf("%v is not equivalent to itself", reference)
+			}
+		})
+	}
+}
+
+func TestWeeklyIntervalsEquivalentKeepsNonCanonicalConfig(t *testing.T) {
+	// adjacent intervals & days listed separately come back merged & regrouped from the API
+	configured := []client.WeeklyInterval{
+		{Days: []string{"Mo"}, FromMinute: 21 * 60, ToMinute: 22 * 60, Enabled: true},
+		{Days: []string{"Mo"}, FromMinute: 22 * 60, ToMinute: 1439, Enabled: true},
+		{Days: []string{"Tu"}, FromMinute: 21 * 60, ToMinute: 1439, Enabled: true},
+	}
+
+	stored, err := convertWeeklyIntervalsToReference(configured, "America/New_York", schedulerWinter)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	read, err := convertWeeklyIntervalsFromReference(stored, "America/New_York", schedulerWinter)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if reflect.DeepEqual(read, configured) {
+		t.Fatalf("expected the conversion to change the shape of the configured intervals")
+	}
+
+	expected, err := convertWeeklyIntervalsToReference(configured, "America/New_York", schedulerWinter)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !weeklyIntervalsEquivalent(expected, stored) {
+		t.Fatalf("configured intervals %v should be equivalent to the stored %v", configured, stored)
+	}
+}
+
+func TestWeeklyIntervalsEquivalentDetectsDSTChange(t *testing.T) {
+	configured := []client.WeeklyInterval{{Days: []string{"Mo"}, FromMinute: 9 * 60, ToMinute: 17 * 60, Enabled: true}}
+
+	stored, err := convertWeeklyIntervalsToReference(configured, "America/New_York", schedulerWinter)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected, err := convertWeeklyIntervalsToReference(configured, "America/New_York", schedulerSummer)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if weeklyIntervalsEquivalent(expected, stored) {
+		t.Fatalf("intervals stored with the winter offset should differ from the summer offset")
+	}
+}
+
+func TestWeeklyIntervalsEquivalent(t *testing.T) {
+	monday := client.WeeklyInterval{Days: []string{"Mo"}, FromMinute: 60, ToMinute: 120, Enabled: true}
+
+	cases := []struct {
+		name string
+		a    []client.WeeklyInterval
+		b    []client.WeeklyInterval
+		want bool
+	}{
+		{"same", []client.WeeklyInterval{monday}, []client.WeeklyInterval{monday}, true},
+		{"days grouped", []client.WeeklyInterval{
+			{Days: []string{"Mo"}, FromMinute: 60, ToMinute: 120, Enabled: true},
+			{Days: []string{"Tu"}, FromMinute: 60, ToMinute: 120, Enabled: true},
+		}, []client.WeeklyInterval{
+			{Days: []string{"Mo", "Tu"}, FromMinute: 60, ToMinute: 120, Enabled: true},
+		}, true},
+		{"split", []client.WeeklyInterval{
+			{Days: []string{"Mo"}, FromMinute: 60, ToMinute: 90, Enabled: true},
+			{Days: []string{"Mo"}, FromMinute: 90, ToMinute: 120, Enabled: true},
+		}, []client.WeeklyInterval{monday}, true},
+		{"different times", []client.WeeklyInterval{monday}, []client.WeeklyInterval{
+			{Days: []string{"Mo"}, FromMinute: 60, ToMinute: 121, Enabled: true},
+		}, false},
+		{"different enabled", []client.WeeklyInterval{monday}, []client.WeeklyInterval{
+			{Days: []string{"Mo"}, FromMinute: 60, ToMinute: 120, Enabled: false},
+		}, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := weeklyIntervalsEquivalent(tc.a, tc.b); got != tc.want {
+				t.Fatalf("weeklyIntervalsEquivalent(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,375 @@
+package dotcommonitor
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// recurrenceMaxPeriods ... upper bound on the number of FREQ periods walked while expanding a rule, guards against rules that never match
+const recurrenceMaxPeriods = 100000
+
+// recurrenceUntilLayouts ... RFC 5545 DATE-TIME & DATE layouts accepted for UNTIL
+var recurrenceUntilLayouts = []string{"20060102T150405Z", "20060102T150405", "20060102"}
+
+// recurrenceWeekdays ... RFC 5545 weekday codes
+var recurrenceWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// recurrenceWeekday ... a BYDAY entry, e.g. "1SU" is the first Sunday & "-1FR" the last Friday, an Ordinal of 0 means every such weekday
+type recurrenceWeekday struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// recurrenceRule ... the subset of an RFC 5545 RRULE supported for recurring exclusions
+type recurrenceRule struct {
+	Freq       string
+	Interval   int
+	Count      int
+	Until      *time.Time
+	ByMonth    []int
+	ByMonthDay []int
+	ByDay      []recurrenceWeekday
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+// parseRecurrenceRule ... parses an RRULE such as "FREQ=MONTHLY;BYDAY=1SU", with or without the "RRULE:" prefix
+//
+// Supported parts are FREQ (DAILY, WEEKLY, MONTHLY & YEARLY), INTERVAL, COUNT, UNTIL,
+// BYMONTH, BYMONTHDAY, BYDAY, BYSETPOS & WKST. The time of day comes from the start
+// of the recurring exclusion, so BYHOUR, BYMINUTE & BYSECOND are not supported.
+func parseRecurrenceRule(s string) (*recurrenceRule, error) {
+	rule := &recurrenceRule{Interval: 1, WeekStart: time.Monday}
+
+	v := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	if v == "" {
+		return nil, fmt.Errorf("RRULE must not be empty")
+	}
+
+	for _, part := range strings.Split(v, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return nil, fmt.Errorf("%q is not a valid RRULE part", part)
+		}
+		key, value := kv[0], kv[1]
+
+		var err error
+		switch key {
+		case "FREQ":
+			switch value {
+			case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+				rule.Freq = value
+			default:
+				return nil, fmt.Errorf("FREQ=%s is not supported, must be one of DAILY, WEEKLY, MONTHLY or YEARLY", value)
+			}
+		case "INTERVAL":
+			rule.Interval, err = strconv.Atoi(value)
+			if err != nil || rule.Interval < 1 {
+				return nil, fmt.Errorf("INTERVAL must be a positive integer, got %q", value)
+			}
+		case "COUNT":
+			rule.Count, err = strconv.Atoi(value)
+			if err != nil || rule.Count < 1 {
+				return nil, fmt.Errorf("COUNT must be a positive integer, got %q", value)
+			}
+		case "UNTIL":
+			for _, layout := range recurrenceUntilLayouts {
+				if t, errParse := time.Parse(layout, value); errParse == nil {
+					rule.Until = &t
+					break
+				}
+			}
+			if rule.Until == nil {
+				return nil, fmt.Errorf("UNTIL must be in the format YYYYMMDD or YYYYMMDDThhmmssZ, got %q", value)
+			}
+		case "BYMONTH":
+			rule.ByMonth, err = parseRecurrenceIntList(key, value, 1, 12, false)
+		case "BYMONTHDAY":
+			rule.ByMonthDay, err = parseRecurrenceIntList(key, value, 1, 31, true)
+		case "BYSETPOS":
+			rule.BySetPos, err = parseRecurrenceIntList(key, value, 1, 366, true)
+		case "BYDAY":
+			for _, item := range strings.Split(value, ",") {
+				if len(item) < 2 {
+					return nil, fmt.Errorf("%q is not a valid BYDAY value", item)
+				}
+				weekday, ok := recurrenceWeekdays[item[len(item)-2:]]
+				if !ok {
+					return nil, fmt.Errorf("%q is not a valid BYDAY value", item)
+				}
+				ordinal := 0
+				if prefix := item[:len(item)-2]; prefix != "" {
+					ordinal, err = strconv.Atoi(prefix)
+					if err != nil || ordinal == 0 || ordinal < -53 || ordinal > 53 {
+						return nil, fmt.Errorf("%q is not a valid BYDAY value", item)
+					}
+				}
+				rule.ByDay = append(rule.ByDay, recurrenceWeekday{Ordinal: ordinal, Weekday: weekday})
+			}
+		case "WKST":
+			weekday, ok := recurrenceWeekdays[value]
+			if !ok {
+				return nil, fmt.Errorf("%q is not a valid WKST value", value)
+			}
+			rule.WeekStart = weekday
+		default:
+			return nil, fmt.Errorf("RRULE part %s is not supported", key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("RRULE must include FREQ")
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("RRULE must not include both COUNT and UNTIL")
+	}
+	for _, item := range rule.ByDay {
+		if item.Ordinal != 0 && rule.Freq != "MONTHLY" && rule.Freq != "YEARLY" {
+			return nil, fmt.Errorf("BYDAY ordinals are only supported with FREQ=MONTHLY or FREQ=YEARLY")
+		}
+	}
+	if len(rule.ByMonthDay) > 0 && rule.Freq == "WEEKLY" {
+		return nil, fmt.Errorf("BYMONTHDAY is not supported with FREQ=WEEKLY")
+	}
+
+	return rule, nil
+}
+
+// parseRecurrenceIntList ... parses a comma separated list of integers between min & max, optionally allowing negative values
+func parseRecurrenceIntList(key, value string, min, max int, allowNegative bool) ([]int, error) {
+	var l []int
+	for _, item := range strings.Split(value, ",") {
+		n, err := strconv.Atoi(item)
+		abs := n
+		if abs < 0 && allowNegative {
+			abs = -abs
+		}
+		if err != nil || abs < min || abs > max {
+			return nil, fmt.Errorf("%s value %q must be between %v and %v", key, item, min, max)
+		}
+		l = append(l, n)
+	}
+	return l, nil
+}
+
+// occurrences ... returns the start of every occurrence of the rule that begins at or after from & before to
+//
+// The first occurrence is dtstart (when it matches the rule), and every occurrence
+// keeps the wall clock time of dtstart in its location, so daylight saving time
+// changes do not move the occurrences.
+func (r *recurrenceRule) occurrences(dtstart, from, to time.Time) []time.Time {
+	var result []time.Time
+	count := 0
+	loc := dtstart.Location()
+
+	for period := 0; period < recurrenceMaxPeriods; period++ {
+		periodStart, days := r.periodDays(dtstart, period*r.Interval)
+		if periodStart.After(to) {
+			break
+		}
+
+		for _, day := range days {
+			t := time.Date(day.Year(), day.Month(), day.Day(), dtstart.Hour(), dtstart.Minute(), dtstart.Second(), 0, loc)
+			if t.Before(dtstart) {
+				continue
+			}
+			if r.Until != nil && t.After(*r.Until) {
+				return result
+			}
+			count++
+			if r.Count > 0 && count > r.Count {
+				return result
+			}
+			if !t.Before(to) {
+				return result
+			}
+			if !t.Before(from) {
+				result = append(result, t)
+			}
+		}
+	}
+
+	return result
+}
+
+// periodDays ... returns the first day of the n-th FREQ period after the one containing dtstart, along with the sorted days in it that match the rule
+func (r *recurrenceRule) periodDays(dtstart time.Time, n int) (time.Time, []time.Time) {
+	start := time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day(), 0, 0, 0, 0, time.UTC)
+	var periodStart time.Time
+	var days []time.Time
+
+	switch r.Freq {
+	case "DAILY":
+		day := start.AddDate(0, 0, n)
+		periodStart = day
+		if r.matchesMonth(day) && r.matchesMonthDay(day) && r.matchesWeekday(day) {
+			days = append(days, day)
+		}
+	case "WEEKLY":
+		weekStart := start.AddDate(0, 0, -((int(start.Weekday())-int(r.WeekStart))+7)%7).AddDate(0, 0, 7*n)
+		periodStart = weekStart
+		for i := 0; i < 7; i++ {
+			day := weekStart.AddDate(0, 0, i)
+			if len(r.ByDay) == 0 && day.Weekday() != start.Weekday() {
+				continue
+			}
+			if r.matchesMonth(day) && r.matchesWeekday(day) {
+				days = append(days, day)
+			}
+		}
+	case "MONTHLY":
+		month := time.Date(start.Year(), start.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+		periodStart = month
+		if r.matchesMonth(month) {
+			days = r.monthDays(month, start.Day())
+		}
+	case "YEARLY":
+		year := start.Year() + n
+		periodStart = time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+		if len(r.ByDay) > 0 && len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0 {
+			days = r.spanWeekdays(time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(year+1, 1, 1, 0, 0, 0, 0, time.UTC))
+			break
+		}
+		months := r.ByMonth
+		if len(months) == 0 {
+			months = []int{int(start.Month())}
+		}
+		for _, m := range months {
+			days = append(days, r.monthDays(time.Date(year, time.Month(m), 1, 0, 0, 0, 0, time.UTC), start.Day())...)
+		}
+	}
+
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	return periodStart, r.applySetPos(days)
+}
+
+// monthDays ... returns the days of the month matching BYMONTHDAY & BYDAY, or the day of month of the start when neither is set
+func (r *recurrenceRule) monthDays(month time.Time, startDay int) []time.Time {
+	next := month.AddDate(0, 1, 0)
+	lastDay := next.AddDate(0, 0, -1).Day()
+	var days []time.Time
+
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		if startDay <= lastDay {
+			days = append(days, time.Date(month.Year(), month.Month(), startDay, 0, 0, 0, 0, time.UTC))
+		}
+		return days
+	}
+
+	if len(r.ByDay) > 0 {
+		days = r.spanWeekdays(month, next)
+		if len(r.ByMonthDay) == 0 {
+			return days
+		}
+	} else {
+		for d := 1; d <= lastDay; d++ {
+			days = append(days, time.Date(month.Year(), month.Month(), d, 0, 0, 0, 0, time.UTC))
+		}
+	}
+
+	var filtered []time.Time
+	for _, day := range days {
+		for _, md := range r.ByMonthDay {
+			if md == day.Day() || (md < 0 && lastDay+md+1 == day.Day()) {
+				filtered = append(filtered, day)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// spanWeekdays ... returns the days in [from, to) matching BYDAY, with ordinals counted within the span
+func (r *recurrenceRule) spanWeekdays(from, to time.Time) []time.Time {
+	byWeekday := make(map[time.Weekday][]time.Time)
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		byWeekday[day.Weekday()] = append(byWeekday[day.Weekday()], day)
+	}
+
+	var days []time.Time
+	for _, item := range r.ByDay {
+		matching := byWeekday[item.Weekday]
+		switch {
+		case item.Ordinal == 0:
+			days = append(days, matching...)
+		case item.Ordinal > 0 && item.Ordinal <= len(matching):
+			days = append(days, matching[item.Ordinal-1])
+		case item.Ordinal < 0 && -item.Ordinal <= len(matching):
+			days = append(days, matching[len(matching)+item.Ordinal])
+		}
+	}
+	return days
+}
+
+// applySetPos ... keeps only the BYSETPOS positions of the sorted days of a period
+func (r *recurrenceRule) applySetPos(days []time.Time) []time.Time {
+	if len(r.BySetPos) == 0 {
+		return days
+	}
+
+	var result []time.Time
+	for _, pos := range r.BySetPos {
+		switch {
+		case pos > 0 && pos <= len(days):
+			result = append(result, days[pos-1])
+		case pos < 0 && -pos <= len(days):
+			result = append(result, days[len(days)+pos])
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return result
+}
+
+// matchesMonth ... checks the day against BYMONTH
+func (r *recurrenceRule) matchesMonth(day time.Time) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		if time.Month(m) == day.Month() {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesMonthDay ... checks the day against BYMONTHDAY
+func (r *recurrenceRule) matchesMonthDay(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	lastDay := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, md := range r.ByMonthDay {
+		if md == day.Day() || (md < 0 && lastDay+md+1 == day.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesWeekday ... checks the day against BYDAY, ignoring ordinals
+func (r *recurrenceRule) matchesWeekday(day time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, item := range r.ByDay {
+		if item.Weekday == day.Weekday() {
+			return true
+		}
+	}
+	return false
+}
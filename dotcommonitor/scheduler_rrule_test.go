@@ -0,0 +1,181 @@
+package dotcommonitor
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRecurrenceRuleOccurrences(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("unable to load timezone: %s", err)
+	}
+	utc := func(s string) time.Time {
+		v, err := time.Parse("2006-01-02 15:04", s)
+		if err != nil {
+			t.Fatalf("invalid test time %q: %s", s, err)
+		}
+		return v
+	}
+
+	cases := []struct {
+		name    string
+		rrule   string
+		dtstart time.Time
+		from    time.Time // defaults to dtstart
+		to      time.Time
+		want    []string // UTC, "2006-01-02 15:04"
+	}{
+		{
+			name:    "first Sunday of the month",
+			rrule:   "FREQ=MONTHLY;BYDAY=1SU",
+			dtstart: utc("2021-01-03 02:00"),
+			to:      utc("2021-05-01 00:00"),
+			want:    []string{"2021-01-03 02:00", "2021-02-07 02:00", "2021-03-07 02:00", "2021-04-04 02:00"},
+		},
+		{
+			name:    "last Friday of the month",
+			rrule:   "RRULE:FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart: utc("2021-01-29 22:00"),
+			to:      utc("2021-05-01 00:00"),
+			want:    []string{"2021-01-29 22:00", "2021-02-26 22:00", "2021-03-26 22:00", "2021-04-30 22:00"},
+		},
+		{
+			name:    "dtstart that does not match the rule is skipped",
+			rrule:   "FREQ=MONTHLY;BYDAY=1SU",
+			dtstart: utc("2021-01-05 02:00"),
+			to:      utc("2021-03-01 00:00"),
+			want:    []string{"2021-02-07 02:00"},
+		},
+		{
+			name:    "last weekday of the month with BYSETPOS",
+			rrule:   "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			dtstart: utc("2021-01-29 03:00"),
+			to:      utc("2021-05-01 00:00"),
+			want:    []string{"2021-01-29 03:00", "2021-02-26 03:00", "2021-03-31 03:00", "2021-04-30 03:00"},
+		},
+		{
+			name:    "second weekday of the month with BYSETPOS",
+			rrule:   "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=2",
+			dtstart: utc("2021-05-01 03:00"),
+			to:      utc("2021-07-01 00:00"),
+			want:    []string{"2021-05-04 03:00", "2021-06-02 03:00"},
+		},
+		{
+			name:    "COUNT limits the occurrences",
+			rrule:   "FREQ=DAILY;COUNT=3",
+			dtstart: utc("2021-03-01 01:00"),
+			to:      utc("2022-01-01 00:00"),
+			want:    []string{"2021-03-01 01:00", "2021-03-02 01:00", "2021-03-03 01:00"},
+		},
+		{
+			name:    "COUNT includes occurrences before from",
+			rrule:   "FREQ=DAILY;COUNT=5",
+			dtstart: utc("2021-03-01 01:00"),
+			from:    utc("2021-03-03 00:00"),
+			to:      utc("2022-01-01 00:00"),
+			want:    []string{"2021-03-03 01:00", "2021-03-04 01:00", "2021-03-05 01:00"},
+		},
+		{
+			name:    "UNTIL before the time of day excludes the last day",
+			rrule:   "FREQ=DAILY;UNTIL=20210303T000000Z",
+			dtstart: utc("2021-03-01 01:00"),
+			to:      utc("2022-01-01 00:00"),
+			want:    []string{"2021-03-01 01:00", "2021-03-02 01:00"},
+		},
+		{
+			name:    "UNTIL is inclusive",
+			rrule:   "FREQ=DAILY;UNTIL=20210303T010000Z",
+			dtstart: utc("2021-03-01 01:00"),
+			to:      utc("2022-01-01 00:00"),
+			want:    []string{"2021-03-01 01:00", "2021-03-02 01:00", "2021-03-03 01:00"},
+		},
+		{
+			name:    "every other week on two days",
+			rrule:   "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE",
+			dtstart: utc("2021-03-01 09:00"),
+			to:      utc("2021-04-01 00:00"),
+			want:    []string{"2021-03-01 09:00", "2021-03-03 09:00", "2021-03-15 09:00", "2021-03-17 09:00", "2021-03-29 09:00", "2021-03-31 09:00"},
+		},
+		{
+			name:    "every third month",
+			rrule:   "FREQ=MONTHLY;INTERVAL=3",
+			dtstart: utc("2021-01-15 04:00"),
+			to:      utc("2021-12-01 00:00"),
+			want:    []string{"2021-01-15 04:00", "2021-04-15 04:00", "2021-07-15 04:00", "2021-10-15 04:00"},
+		},
+		{
+			name:    "monthly on a day some months do not have",
+			rrule:   "FREQ=MONTHLY",
+			dtstart: utc("2021-01-31 04:00"),
+			to:      utc("2021-06-01 00:00"),
+			want:    []string{"2021-01-31 04:00", "2021-03-31 04:00", "2021-05-31 04:00"},
+		},
+		{
+			name:    "yearly in a month",
+			rrule:   "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH",
+			dtstart: utc("2021-11-25 12:00"),
+			to:      utc("2024-01-01 00:00"),
+			want:    []string{"2021-11-25 12:00", "2022-11-24 12:00", "2023-11-23 12:00"},
+		},
+		{
+			name:    "keeps the wall clock time across a DST change",
+			rrule:   "FREQ=WEEKLY;BYDAY=SU",
+			dtstart: time.Date(2021, 3, 7, 3, 0, 0, 0, newYork),
+			to:      utc("2021-03-22 00:00"),
+			want:    []string{"2021-03-07 08:00", "2021-03-14 07:00", "2021-03-21 07:00"},
+		},
+		{
+			name:    "keeps the wall clock time across the DST change back",
+			rrule:   "FREQ=MONTHLY;BYDAY=1SU",
+			dtstart: time.Date(2021, 10, 3, 3, 0, 0, 0, newYork),
+			to:      utc("2021-12-01 00:00"),
+			want:    []string{"2021-10-03 07:00", "2021-11-07 08:00"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule, err := parseRecurrenceRule(tc.rrule)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			from := tc.from
+			if from.IsZero() {
+				from = tc.dtstart
+			}
+
+			got := []string{}
+			for _, item := range rule.occurrences(tc.dtstart, from, tc.to) {
+				got = append(got, item.UTC().Format("2006-01-02 15:04"))
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("occurrences = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseRecurrenceRuleErrors(t *testing.T) {
+	cases := []string{
+		"",
+		"BYDAY=MO",
+		"FREQ=HOURLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20210101",
+		"FREQ=DAILY;UNTIL=2021-01-01",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYDAY=XX",
+		"FREQ=MONTHLY;BYMONTHDAY=32",
+		"FREQ=MONTHLY;BYHOUR=2",
+		"FREQ=MONTHLY;BYSETPOS",
+	}
+
+	for _, item := range cases {
+		if _, err := parseRecurrenceRule(item); err == nil {
+			t.Errorf("parseRecurrenceRule(%q) succeeded, want an error", item)
+		}
+	}
+}
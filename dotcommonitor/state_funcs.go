@@ -12,3 +12,14 @@ func StateToLower(v interface{}) string {
 
 	return strings.ToLower(s)
 }
+
+// StateGroupAddressType ... converts a group address type to the case the API uses, e.g. "slack" to "Slack"
+func StateGroupAddressType(v interface{}) string {
+	s, ok := v.(string)
+
+	if !ok {
+		return ""
+	}
+
+	return canonicalGroupAddressType(s)
+}
@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -131,69 +132,78 @@ func validateWeeklyIntervalTo() schema.SchemaValidateFunc {
 func validateExcludedTimeIntervalTimestamp(i interface{}, k string) (ws []string, errors []error) {
 	v := i.(string)
 
-	// validate input can be parsed to iso8601
-	_, err := time.Parse(schedulerExcludedTimeIntervalLayout, v)
+	// validate input can be parsed as an RFC 3339 timestamp or a relative expression
+	_, err := parseExcludedTimeIntervalTimestamp(v, time.Now())
 	if err != nil {
-		errors = append(errors, fmt.Errorf("%q cannot be parsed as iso8601 Timestamp Format", v))
+		errors = append(errors, fmt.Errorf("%s: %s", k, err))
 	}
 
 	return
 }
 
-//////////////////////////////
-// Filter validators
-//////////////////////////////
+// validateRecurrenceRule ... ensure the recurring exclusion RRULE is valid & supported
+func validateRecurrenceRule(i interface{}, k string) (ws []string, errors []error) {
+	v := i.(string)
+
+	if _, err := parseRecurrenceRule(v); err != nil {
+		errors = append(errors, fmt.Errorf("%s: %s", k, err))
+	}
 
-// validateIgnoreErrorsCodes ... ensures the ignore errors codes are valid
-func validateIgnoreErrorsCodes(i interface{}, k string) (ws []string, errors []error) {
-	v, ok := i.(string)
+	return
+}
 
-	// first validate it is of string type
-	if !ok {
-		errors = append(errors, fmt.Errorf("expected type of %s to be string", k))
+// validateRecurringExclusionStart ... ensure the recurring exclusion start is an absolute timestamp
+func validateRecurringExclusionStart(i interface{}, k string) (ws []string, errors []error) {
+	v := i.(string)
+
+	if isRelativeExcludedTimeInterval(v) {
+		errors = append(errors, fmt.Errorf("%s: %q must be an absolute timestamp, relative expressions are not supported", k, v))
 		return
 	}
+	if _, err := parseExcludedTimeIntervalTimestamp(v, time.Now()); err != nil {
+		errors = append(errors, fmt.Errorf("%s: %s", k, err))
+	}
 
-	parts := strings.Split(v, IgnoreErrorsCodesSeparator)
-
-	for _, item := range parts {
-		var singleErrors []error
-		var rangeErrors []error
-
-		// first see if current item is a single error code
-		if _, errSingle := strconv.Atoi(item); errSingle != nil {
-			// item not a valid single code
-			singleErrors = append(singleErrors, fmt.Errorf("%s: \"%v\" unable to parse input as single error code", k, v))
-
-			// try item as a range
-			r := strings.Split(item, IgnoreErrorsCodesRangeSeparator)
-			if len(r) == 2 {
-				// validate if input string is a range (ex: 400-499)
-				// ensure each part of the range is a valid integer
-				from, errFrom := strconv.Atoi(r[0])
-				to, errTo := strconv.Atoi(r[1])
-				if errFrom != nil {
-					rangeErrors = append(rangeErrors, fmt.Errorf("%s: \"%v\" left side \"%v\" of code range is not a valid number", k, v, from))
-				}
-				if errTo != nil {
-					rangeErrors = append(rangeErrors, fmt.Errorf("%s: \"%v\" right side \"%v\" of code range is not a valid number", k, v, to))
-				}
-
-				// then validate first part of code range is smaller than the second part
-				if from >= to {
-					rangeErrors = append(rangeErrors, fmt.Errorf("%s: \"%v\" left side \"%v\" of code range must be smaller than the right side \"%v\"", k, v, from, to))
-				}
-			} else {
-				// range doesn't include two codes
-				rangeErrors = append(rangeErrors, fmt.Errorf("%s: \"%v\" a valid range must have two codes separated by a hyphen", k, v))
-			}
+	return
+}
 
-			// bubble up errors only if all parsing attempts fail
-			if len(singleErrors) > 0 && len(rangeErrors) > 0 {
-				errors = append(errors, singleErrors...)
-				errors = append(errors, rangeErrors...)
-			}
-		}
+// validateRecurringExclusionDuration ... ensure the recurring exclusion duration is a positive duration, optionally in days
+func validateRecurringExclusionDuration(i interface{}, k string) (ws []string, errors []error) {
+	v := i.(string)
+
+	d, err := parseRelativeDuration(v)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%s: %s", k, err))
+		return
+	}
+	if d <= 0 {
+		errors = append(errors, fmt.Errorf("%s: %q must be greater than zero", k, v))
+	}
+
+	return
+}
+
+// validateSchedulerTimezone ... ensure the scheduler timezone is a known IANA timezone name
+func validateSchedulerTimezone(i interface{}, k string) (ws []string, errors []error) {
+	v := i.(string)
+
+	if _, err := time.LoadLocation(v); err != nil || v == "" || v == "Local" {
+		errors = append(errors, fmt.Errorf("%s: %q is not a valid IANA timezone name (for example, \"Europe/Berlin\")", k, v))
+	}
+
+	return
+}
+
+//////////////////////////////
+// Location validators
+//////////////////////////////
+
+// validateCountryCode ... ensure the country is an upper case ISO 3166-1 alpha-2 code
+func validateCountryCode(i interface{}, k string) (ws []string, errors []error) {
+	v := i.(string)
+
+	if len(v) != 2 || strings.ToUpper(v) != v || !unicode.IsLetter(rune(v[0])) || !unicode.IsLetter(rune(v[1])) {
+		errors = append(errors, fmt.Errorf("%s: %q must be an upper case ISO 3166-1 alpha-2 country code, e.g. US", k, v))
 	}
 
 	return